@@ -0,0 +1,176 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// archiveEntry is a regular file inside of an archive
+type archiveEntry struct {
+	name string
+	data []byte
+}
+
+func isGzip(data []byte) bool {
+	return bytes.HasPrefix(data, []byte{0x1f, 0x8b})
+}
+
+func isZip(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04"))
+}
+
+// isTar checks for the magic string of POSIX (ustar) and GNU tar headers,
+// which is located at offset 257 of the first header block
+func isTar(data []byte) bool {
+	return len(data) >= 512 && bytes.HasPrefix(data[257:], []byte("ustar"))
+}
+
+func isArchive(data []byte) bool {
+	return isTar(data) || isZip(data)
+}
+
+// decompress returns the uncompressed data of a gzip stream, or the input
+// data as-is in case it is not compressed
+func decompress(data []byte) ([]byte, error) {
+	if !isGzip(data) {
+		return data, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return readLimited(reader)
+}
+
+// readLimited reads all data from the reader, but fails once more than
+// `MaxDecompressedSize` bytes are read
+func readLimited(reader io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, MaxDecompressedSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > MaxDecompressedSize {
+		return nil, fmt.Errorf("decompressed data exceeds the limit of %d bytes", MaxDecompressedSize)
+	}
+
+	return data, nil
+}
+
+// readArchive returns all regular files of a tar or zip archive sorted by
+// their name, directories and other special entries are skipped
+func readArchive(data []byte) ([]archiveEntry, error) {
+	var (
+		entries []archiveEntry
+		err     error
+	)
+
+	switch {
+	case isZip(data):
+		entries, err = readZipArchive(data)
+
+	default:
+		entries, err = readTarArchive(data)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return strings.Compare(entries[i].name, entries[j].name) < 0
+	})
+
+	return entries, nil
+}
+
+func readTarArchive(data []byte) ([]archiveEntry, error) {
+	var entries []archiveEntry
+
+	reader := tar.NewReader(bytes.NewReader(data))
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, archiveEntry{name: header.Name, data: content})
+	}
+
+	return entries, nil
+}
+
+func readZipArchive(data []byte) ([]archiveEntry, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []archiveEntry
+	for _, file := range reader.File {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+
+		content, err := readZipFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, archiveEntry{name: file.Name, data: content})
+	}
+
+	return entries, nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return readLimited(reader)
+}
@@ -0,0 +1,296 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// Canonicalize returns a canonical copy of the provided YAML tree, in which
+// aliases and merge keys (`<<`) are resolved, map keys are sorted, and scalars
+// use a normalized representation based on their resolved type: `yes` and
+// `on` become `true`, `0x1F` becomes `31`, `~` becomes `null`, and quoting
+// styles, anchors, comments, and positions are removed. Two trees with the
+// same canonical form are semantically identical. The order of list entries
+// is retained.
+func Canonicalize(node *yamlv3.Node) *yamlv3.Node {
+	if node == nil {
+		return nil
+	}
+
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		result := &yamlv3.Node{Kind: yamlv3.DocumentNode}
+		for _, content := range node.Content {
+			result.Content = append(result.Content, Canonicalize(content))
+		}
+
+		return result
+
+	case yamlv3.AliasNode:
+		return Canonicalize(node.Alias)
+
+	case yamlv3.MappingNode:
+		type keyValuePair struct{ key, value *yamlv3.Node }
+
+		content := mergedContent(node)
+		pairs := make([]keyValuePair, 0, len(content)/2)
+		for i := 0; i+1 < len(content); i += 2 {
+			pairs = append(pairs, keyValuePair{
+				key:   Canonicalize(content[i]),
+				value: Canonicalize(content[i+1]),
+			})
+		}
+
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return compareCanonicalNodes(pairs[i].key, pairs[j].key) < 0
+		})
+
+		result := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: node.ShortTag()}
+		for _, pair := range pairs {
+			result.Content = append(result.Content, pair.key, pair.value)
+		}
+
+		return result
+
+	case yamlv3.SequenceNode:
+		result := &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: node.ShortTag()}
+		for _, entry := range node.Content {
+			result.Content = append(result.Content, Canonicalize(entry))
+		}
+
+		return result
+
+	default:
+		tag, value := canonicalScalar(node)
+		return &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: tag, Value: value}
+	}
+}
+
+// Hash returns a stable SHA-256 digest (hex encoded) of the canonical form of
+// the provided YAML tree, so that semantically identical trees have the same
+// hash regardless of key order, scalar styles, or comments
+func Hash(node *yamlv3.Node) string {
+	digest := sha256.New()
+	writeCanonical(digest, Canonicalize(node))
+	return hex.EncodeToString(digest.Sum(nil))
+}
+
+// tagMerge is the tag of the YAML merge key `<<`
+const tagMerge = "!!merge"
+
+// mergedContent returns the keys and values of a map with the YAML merge keys
+// (`<<`) expanded, where the keys of the map itself take precedence over the
+// merged keys, and earlier maps of a merge list over later ones
+func mergedContent(mappingNode *yamlv3.Node) []*yamlv3.Node {
+	var content, sources []*yamlv3.Node
+	for i := 0; i+1 < len(mappingNode.Content); i += 2 {
+		key, value := mappingNode.Content[i], resolveAlias(mappingNode.Content[i+1])
+		if key.Kind != yamlv3.ScalarNode || key.ShortTag() != tagMerge {
+			content = append(content, key, mappingNode.Content[i+1])
+			continue
+		}
+
+		switch value.Kind {
+		case yamlv3.MappingNode:
+			sources = append(sources, value)
+
+		case yamlv3.SequenceNode:
+			for _, entry := range value.Content {
+				sources = append(sources, resolveAlias(entry))
+			}
+		}
+	}
+
+	if len(sources) == 0 {
+		return mappingNode.Content
+	}
+
+	seen := map[string]struct{}{}
+	for i := 0; i < len(content); i += 2 {
+		if id, ok := mergeKeyID(content[i]); ok {
+			seen[id] = struct{}{}
+		}
+	}
+
+	for _, source := range sources {
+		if source.Kind != yamlv3.MappingNode {
+			continue
+		}
+
+		merged := mergedContent(source)
+		for i := 0; i+1 < len(merged); i += 2 {
+			if id, ok := mergeKeyID(merged[i]); ok {
+				if _, found := seen[id]; found {
+					continue
+				}
+
+				seen[id] = struct{}{}
+			}
+
+			content = append(content, merged[i], merged[i+1])
+		}
+	}
+
+	return content
+}
+
+// mergeKeyID returns a string that identifies a scalar key of a map, which is
+// used to decide whether a merged key is already defined
+func mergeKeyID(key *yamlv3.Node) (string, bool) {
+	key = resolveAlias(key)
+	if key.Kind != yamlv3.ScalarNode {
+		return "", false
+	}
+
+	tag, value := canonicalScalar(key)
+	return tag + ":" + value, true
+}
+
+// canonicalScalar returns the resolved tag and normalized value of a scalar
+func canonicalScalar(node *yamlv3.Node) (string, string) {
+	tag := node.ShortTag()
+
+	switch tag {
+	case tagBool:
+		var value bool
+		if err := node.Decode(&value); err == nil {
+			return tag, strconv.FormatBool(value)
+		}
+
+	case tagInt:
+		var value int64
+		if err := node.Decode(&value); err == nil {
+			return tag, strconv.FormatInt(value, 10)
+		}
+
+		var unsigned uint64
+		if err := node.Decode(&unsigned); err == nil {
+			return tag, strconv.FormatUint(unsigned, 10)
+		}
+
+	case tagFloat:
+		var value float64
+		if err := node.Decode(&value); err == nil {
+			return tag, formatCanonicalFloat(value)
+		}
+
+	case tagNull:
+		return tag, "null"
+
+	case tagString:
+		// Plain YAML 1.1 booleans are still commonly used and meant as booleans
+		if node.Style&(yamlv3.TaggedStyle|yamlv3.SingleQuotedStyle|yamlv3.DoubleQuotedStyle|yamlv3.LiteralStyle|yamlv3.FoldedStyle) == 0 {
+			switch strings.ToLower(node.Value) {
+			case "yes", "on":
+				return tagBool, "true"
+
+			case "no", "off":
+				return tagBool, "false"
+			}
+		}
+	}
+
+	return tag, node.Value
+}
+
+func formatCanonicalFloat(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return ".nan"
+
+	case math.IsInf(value, 1):
+		return ".inf"
+
+	case math.IsInf(value, -1):
+		return "-.inf"
+	}
+
+	result := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(result, ".e") {
+		result += ".0"
+	}
+
+	return result
+}
+
+// compareCanonicalNodes defines the order of map keys in the canonical form,
+// scalars are ordered by value and tag and come before all other keys, which
+// are ordered by their canonical serialization
+func compareCanonicalNodes(a *yamlv3.Node, b *yamlv3.Node) int {
+	aIsScalar, bIsScalar := a.Kind == yamlv3.ScalarNode, b.Kind == yamlv3.ScalarNode
+	switch {
+	case aIsScalar && bIsScalar:
+		if result := strings.Compare(a.Value, b.Value); result != 0 {
+			return result
+		}
+
+		return strings.Compare(a.Tag, b.Tag)
+
+	case aIsScalar != bIsScalar:
+		if aIsScalar {
+			return -1
+		}
+
+		return 1
+	}
+
+	var bufA, bufB strings.Builder
+	writeCanonical(&bufA, a)
+	writeCanonical(&bufB, b)
+	return strings.Compare(bufA.String(), bufB.String())
+}
+
+// writeCanonical writes an unambiguous serialization of a canonical node,
+// where all values are length prefixed
+func writeCanonical(w io.Writer, node *yamlv3.Node) {
+	if node == nil {
+		fmt.Fprint(w, "~")
+		return
+	}
+
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		fmt.Fprintf(w, "d%d:", len(node.Content))
+
+	case yamlv3.MappingNode:
+		fmt.Fprintf(w, "m%d:%d:%s", len(node.Content)/2, len(node.Tag), node.Tag)
+
+	case yamlv3.SequenceNode:
+		fmt.Fprintf(w, "s%d:%d:%s", len(node.Content), len(node.Tag), node.Tag)
+
+	default:
+		fmt.Fprintf(w, "v%d:%s%d:%s", len(node.Tag), node.Tag, len(node.Value), node.Value)
+	}
+
+	for _, content := range node.Content {
+		writeCanonical(w, content)
+	}
+}
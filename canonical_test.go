@@ -0,0 +1,114 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+var _ = Describe("Canonical form", func() {
+	toYAML := func(node *yamlv3.Node) string {
+		out, err := yamlv3.Marshal(node)
+		Expect(err).ToNot(HaveOccurred())
+		return string(out)
+	}
+
+	Context("Canonicalizing YAML trees", func() {
+		It("should sort map keys and remove styles, comments, and positions", func() {
+			node := yml(`---
+# comment
+zulu: "quoted" # comment
+alpha:
+  b: 'single'
+  a: plain
+`)
+			Expect(toYAML(Canonicalize(node))).To(Equal("alpha:\n    a: plain\n    b: single\nzulu: quoted\n"))
+			Expect(Canonicalize(node).Line).To(Equal(0))
+		})
+
+		DescribeTable("should normalize scalars based on their type",
+			func(input string, tag string, value string) {
+				node := Canonicalize(yml(input))
+				Expect(node.Tag).To(Equal(tag))
+				Expect(node.Value).To(Equal(value))
+			},
+
+			Entry("true", "True", "!!bool", "true"),
+			Entry("yes", "yes", "!!bool", "true"),
+			Entry("off", "off", "!!bool", "false"),
+			Entry("quoted yes", `"yes"`, "!!str", "yes"),
+			Entry("hex int", "0x1F", "!!int", "31"),
+			Entry("octal int", "0o17", "!!int", "15"),
+			Entry("int with separators", "1_000", "!!int", "1000"),
+			Entry("float", "1.50", "!!float", "1.5"),
+			Entry("float without decimals", "1e3", "!!float", "1000.0"),
+			Entry("infinity", "+.Inf", "!!float", ".inf"),
+			Entry("null", "~", "!!null", "null"),
+			Entry("quoted number", `"31"`, "!!str", "31"),
+			Entry("custom tag", "!vault secret/foo", "!vault", "secret/foo"),
+		)
+
+		It("should resolve aliases and retain the order of lists", func() {
+			node := yml(`{ base: &base { b: 2, a: 1 }, copy: *base, list: [ z, a ] }`)
+			Expect(Canonicalize(node)).To(BeAsNode(Canonicalize(yml(`{ base: { a: 1, b: 2 }, copy: { a: 1, b: 2 }, list: [ z, a ] }`))))
+			Expect(toYAML(Canonicalize(node))).ToNot(ContainSubstring("*base"))
+		})
+
+		It("should expand merge keys", func() {
+			node := yml(`{ x: &b { a: 1, c: 3 }, y: { <<: *b, c: 4 }, z: { <<: [ { a: 5 }, *b ], d: 6 } }`)
+			Expect(Canonicalize(node)).To(BeAsNode(Canonicalize(yml(`{ x: { a: 1, c: 3 }, y: { a: 1, c: 4 }, z: { a: 5, c: 3, d: 6 } }`))))
+			Expect(Hash(yml(`{ x: &b { a: 1 }, y: { <<: *b } }`))).To(Equal(Hash(yml(`{ x: { a: 1 }, y: { a: 1 } }`))))
+			Expect(Hash(yml(`{ "<<": { a: 1 } }`))).ToNot(Equal(Hash(yml(`{ a: 1 }`))))
+		})
+
+		It("should not modify the input", func() {
+			node := yml(`{ b: 0x1F, a: yes }`)
+			Canonicalize(node)
+			Expect(node).To(BeAsNode(yml(`{ b: 0x1F, a: yes }`)))
+		})
+	})
+
+	Context("Hashing YAML trees", func() {
+		It("should return the same hash for semantically identical trees", func() {
+			a := yml(`---
+name: web # the name
+replicas: 0x3
+enabled: yes
+ports: [ 80, 443 ]
+`)
+			b := yml(`{ "enabled": true, "ports": [ 80, 443 ], "replicas": 3, "name": 'web' }`)
+			Expect(Hash(a)).To(Equal(Hash(b)))
+			Expect(Hash(a)).To(HaveLen(64))
+		})
+
+		It("should return different hashes for different trees", func() {
+			Expect(Hash(yml(`{ a: 1 }`))).ToNot(Equal(Hash(yml(`{ a: "1" }`))))
+			Expect(Hash(yml(`{ a: [ 1, 2 ] }`))).ToNot(Equal(Hash(yml(`{ a: [ 2, 1 ] }`))))
+			Expect(Hash(yml(`{ a: b }`))).ToNot(Equal(Hash(yml(`{ a: { b: ~ } }`))))
+			Expect(Hash(yml(`[ ab, c ]`))).ToNot(Equal(Hash(yml(`[ a, bc ]`))))
+		})
+	})
+})
@@ -22,6 +22,7 @@ package ytbx
 
 import (
 	"reflect"
+	"strings"
 
 	yamlv3 "go.yaml.in/yaml/v3"
 )
@@ -33,10 +34,131 @@ const (
 	typeComplexList = "complex-list"
 )
 
+// ListType is a custom type to distinguish the different kinds of lists
+type ListType int
+
+// Supported list types are plain lists, where all entries are of the same kind,
+// named-entry lists, where all entries are maps with a common identifier, and
+// mixed lists, which have entries of different kinds
+const (
+	NoList ListType = iota
+	PlainList
+	NamedList
+	MixedList
+)
+
+// YAMLType is the structured type information of a YAML node
+type YAMLType struct {
+	// Kind is the node kind, for aliases it is the kind of the aliased node
+	Kind yamlv3.Kind
+
+	// Tag is the resolved short tag, e.g. `!!int`, or a custom tag
+	Tag string
+
+	// List is the list type in case of sequence nodes
+	List ListType
+
+	// Alias is set if the node is an alias of another node
+	Alias bool
+}
+
+// TypeOf returns the structured type information of the provided node, where
+// the tag of scalar values is resolved if it was not explicitly set
+func TypeOf(node *yamlv3.Node) YAMLType {
+	if node == nil {
+		return YAMLType{}
+	}
+
+	if node.Kind == yamlv3.AliasNode && node.Alias != nil {
+		result := TypeOf(node.Alias)
+		result.Alias = true
+		return result
+	}
+
+	result := YAMLType{
+		Kind: node.Kind,
+		Tag:  node.ShortTag(),
+	}
+
+	if node.Kind == yamlv3.SequenceNode {
+		result.List = listTypeOf(node)
+	}
+
+	return result
+}
+
+func listTypeOf(sequenceNode *yamlv3.Node) ListType {
+	for i := 1; i < len(sequenceNode.Content); i++ {
+		if sequenceNode.Content[i].Kind != sequenceNode.Content[0].Kind {
+			return MixedList
+		}
+	}
+
+	if len(sequenceNode.Content) > 0 && GetIdentifierFromNamedList(sequenceNode) != "" {
+		return NamedList
+	}
+
+	return PlainList
+}
+
+// String returns a human readable name of the type, for example `map`, `list`,
+// `named-entry list`, `int`, or `null`
+func (t YAMLType) String() string {
+	var name string
+	switch t.Kind {
+	case 0:
+		name = "nil"
+
+	case yamlv3.DocumentNode:
+		name = "document"
+
+	case yamlv3.MappingNode:
+		name = typeMap
+
+	case yamlv3.SequenceNode:
+		switch t.List {
+		case NamedList:
+			name = "named-entry " + typeSimpleList
+
+		case MixedList:
+			name = "mixed " + typeSimpleList
+
+		default:
+			name = typeSimpleList
+		}
+
+	case yamlv3.ScalarNode:
+		switch t.Tag {
+		case "!!str":
+			name = "string"
+
+		default:
+			name = strings.TrimPrefix(t.Tag, "!!")
+		}
+
+	default:
+		name = "unknown"
+	}
+
+	if t.Alias {
+		return "alias to " + name
+	}
+
+	return name
+}
+
 // GetType returns the type of the input value with a YAML specific view
 func GetType(value interface{}) string {
+	if value == nil {
+		return "nil"
+	}
+
 	switch tobj := value.(type) {
 	case *yamlv3.Node:
+		if tobj == nil {
+			return "nil"
+		}
+
 		switch tobj.Kind {
 		case yamlv3.MappingNode:
 			return typeMap
@@ -68,3 +190,13 @@ func hasMappingNodes(sequenceNode *yamlv3.Node) bool {
 
 	return counter == len(sequenceNode.Content)
 }
+
+// documentRoot returns the root node of a document, or the node itself in
+// case it is not a document node
+func documentRoot(node *yamlv3.Node) *yamlv3.Node {
+	if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+		return node.Content[0]
+	}
+
+	return node
+}
@@ -0,0 +1,76 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+var _ = Describe("Type information", func() {
+	Context("Getting structured type information", func() {
+		DescribeTable("should resolve the type of scalars",
+			func(input string, tag string, name string) {
+				node := yml(input)
+				Expect(TypeOf(node).Kind).To(Equal(yamlv3.ScalarNode))
+				Expect(TypeOf(node).Tag).To(Equal(tag))
+				Expect(TypeOf(node).String()).To(Equal(name))
+			},
+
+			Entry("string", "foobar", "!!str", "string"),
+			Entry("quoted number", `"42"`, "!!str", "string"),
+			Entry("int", "42", "!!int", "int"),
+			Entry("hex int", "0x1F", "!!int", "int"),
+			Entry("float", "3.1415", "!!float", "float"),
+			Entry("bool", "true", "!!bool", "bool"),
+			Entry("null", "~", "!!null", "null"),
+			Entry("timestamp", "2001-12-14t21:59:43.10-05:00", "!!timestamp", "timestamp"),
+			Entry("binary", "!!binary R0lGODlhDAAMAIQAAP", "!!binary", "binary"),
+			Entry("custom tag", "!vault secret/foo", "!vault", "!vault"),
+		)
+
+		It("should distinguish the different kinds of lists", func() {
+			Expect(TypeOf(list(`[ A, B ]`)).List).To(Equal(PlainList))
+			Expect(TypeOf(list(`[ { name: A }, { name: B } ]`)).List).To(Equal(NamedList))
+			Expect(TypeOf(list(`[ { name: A }, B ]`)).List).To(Equal(MixedList))
+			Expect(TypeOf(list(`[ { foo: A }, { foo: B } ]`)).String()).To(Equal("list"))
+			Expect(TypeOf(list(`[ { name: A }, { name: B } ]`)).String()).To(Equal("named-entry list"))
+			Expect(TypeOf(list(`[ { name: A }, B ]`)).String()).To(Equal("mixed list"))
+		})
+
+		It("should flag aliases and report the type of the aliased node", func() {
+			example := yml("{ base: &base { foo: bar }, ref: *base }")
+			ref := example.Content[3]
+			Expect(TypeOf(ref).Alias).To(BeTrue())
+			Expect(TypeOf(ref).Kind).To(Equal(yamlv3.MappingNode))
+			Expect(TypeOf(ref).String()).To(Equal("alias to map"))
+		})
+
+		It("should not panic on nil", func() {
+			Expect(TypeOf(nil).String()).To(Equal("nil"))
+			Expect(GetType(nil)).To(Equal("nil"))
+		})
+	})
+})
@@ -0,0 +1,259 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"fmt"
+	"slices"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// DocumentPairing defines how the documents of two input files are paired
+// with each other for a comparison
+type DocumentPairing int
+
+// Supported pairings are by index, which pairs the first document of one file
+// with the first document of the other file and so on, and by name, which
+// pairs documents with the same name as returned by the document namers, for
+// example Kubernetes resources with the same kind, namespace, and name
+const (
+	PairByIndex DocumentPairing = iota
+	PairByName
+)
+
+// CompareOptions controls how the paths of two input files are compared
+type CompareOptions struct {
+	// Pairing defines which documents of the two files are compared
+	Pairing DocumentPairing
+
+	// Namers are used to name documents when pairing them by name, the
+	// default document namers are used if none are provided
+	Namers []DocumentNamer
+
+	// CompareByValue only reports paths that have the same value in both
+	// documents, where values are compared using `NodesEqual`
+	CompareByValue bool
+
+	// Equality controls the value comparison
+	Equality EqualityOptions
+}
+
+// DocumentPair is a pair of documents from two input files, with the paths
+// that exist in both documents. The paths refer to the second document.
+type DocumentPair struct {
+	FromIdx int
+	ToIdx   int
+	Name    string
+	Paths   []Path
+}
+
+// ComparePathsByDocument pairs the documents of the two input files and
+// returns the paths that exist in both documents of each pair. Documents
+// without a counterpart in the other file are not part of the result.
+func ComparePathsByDocument(from InputFile, to InputFile, opts CompareOptions) []DocumentPair {
+	pairs := pairDocuments(from, to, opts)
+	for i := range pairs {
+		pair := &pairs[i]
+		fromDocument, toDocument := from.Documents[pair.FromIdx], to.Documents[pair.ToIdx]
+
+		lookup := map[string]struct{}{}
+		for _, path := range documentPaths(Path{DocumentIdx: pair.FromIdx}, fromDocument) {
+			lookup[path.ToGoPatchStyle()] = struct{}{}
+		}
+
+		root := Path{DocumentIdx: pair.ToIdx}
+		if opts.Pairing == PairByName {
+			root.DocumentName = pair.Name
+		}
+
+		for _, path := range documentPaths(root, toDocument) {
+			if _, ok := lookup[path.ToGoPatchStyle()]; !ok {
+				continue
+			}
+
+			if opts.CompareByValue && !hasSameValue(fromDocument, toDocument, path, opts.Equality) {
+				continue
+			}
+
+			pair.Paths = append(pair.Paths, path)
+		}
+	}
+
+	return pairs
+}
+
+// pairDocuments returns the pairs of documents that are compared, in the
+// order of the documents of the second input file
+func pairDocuments(from InputFile, to InputFile, opts CompareOptions) []DocumentPair {
+	var pairs []DocumentPair
+
+	switch opts.Pairing {
+	case PairByName:
+		pairs = pairNamedDocuments(NameDocuments(from, opts.Namers...).Names, NameDocuments(to, opts.Namers...).Names)
+
+	default:
+		for idx := 0; idx < len(from.Documents) && idx < len(to.Documents); idx++ {
+			pairs = append(pairs, DocumentPair{FromIdx: idx, ToIdx: idx})
+		}
+	}
+
+	return pairs
+}
+
+// pairNamedDocuments pairs documents with the same name in order of their
+// occurrence, in the order of the names of the second input file
+func pairNamedDocuments(fromNames []string, toNames []string) []DocumentPair {
+	var pairs []DocumentPair
+
+	available := map[string][]int{}
+	for idx, name := range fromNames {
+		available[name] = append(available[name], idx)
+	}
+
+	for idx, name := range toNames {
+		if candidates := available[name]; len(candidates) > 0 {
+			pairs = append(pairs, DocumentPair{FromIdx: candidates[0], ToIdx: idx, Name: name})
+			available[name] = candidates[1:]
+		}
+	}
+
+	return pairs
+}
+
+func documentPaths(root Path, document *yamlv3.Node) []Path {
+	paths := []Path{}
+	traverseTree(root, nil, document, func(path Path, _ *yamlv3.Node, _ *yamlv3.Node) {
+		paths = append(paths, path)
+	})
+
+	return paths
+}
+
+func hasSameValue(from *yamlv3.Node, to *yamlv3.Node, path Path, opts EqualityOptions) bool {
+	fromValue, err := grabByPath(documentRoot(from), path)
+	if err != nil {
+		return false
+	}
+
+	toValue, err := grabByPath(documentRoot(to), path)
+	if err != nil {
+		return false
+	}
+
+	return NodesEqual(fromValue, toValue, opts)
+}
+
+// ComparePathsByValueInFiles returns the paths that have the same value in
+// both input files, where values are compared using `NodesEqual` with the
+// equality options. Paths refer to the documents by name if they have a
+// document name, using the namers of the options, or by index otherwise.
+func ComparePathsByValueInFiles(from InputFile, to InputFile, paths []Path, opts CompareOptions) ([]Path, error) {
+	documents := &documentLookup{from: from, to: to, namers: opts.Namers}
+
+	result := []Path{}
+	for _, path := range paths {
+		fromDocument, toDocument, err := documents.lookup(path)
+		if err != nil {
+			return nil, err
+		}
+
+		fromValue, err := grabByPath(documentRoot(fromDocument), path)
+		if err != nil {
+			return nil, err
+		}
+
+		toValue, err := grabByPath(documentRoot(toDocument), path)
+		if err != nil {
+			return nil, err
+		}
+
+		if NodesEqual(fromValue, toValue, opts.Equality) {
+			result = append(result, path)
+		}
+	}
+
+	return result, nil
+}
+
+// documentLookup finds the documents of both input files that a path refers
+// to, where the documents are named and paired only once
+type documentLookup struct {
+	from    InputFile
+	to      InputFile
+	namers  []DocumentNamer
+	toNames []string
+	pairs   map[int]int
+}
+
+// lookup returns the documents that the path refers to. Paths with a document
+// name refer to the named document of the second input file at the document
+// index, or to the first one with that name, and to the document of the first
+// input file it is paired with. Other paths refer to both documents by index.
+func (l *documentLookup) lookup(path Path) (*yamlv3.Node, *yamlv3.Node, error) {
+	if path.DocumentName == "" {
+		fromDocument, err := documentAt(l.from, path.DocumentIdx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		toDocument, err := documentAt(l.to, path.DocumentIdx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return fromDocument, toDocument, nil
+	}
+
+	if l.pairs == nil {
+		l.toNames = NameDocuments(l.to, l.namers...).Names
+		l.pairs = map[int]int{}
+		for _, pair := range pairNamedDocuments(NameDocuments(l.from, l.namers...).Names, l.toNames) {
+			l.pairs[pair.ToIdx] = pair.FromIdx
+		}
+	}
+
+	toIdx := path.DocumentIdx
+	if toIdx < 0 || toIdx >= len(l.toNames) || l.toNames[toIdx] != path.DocumentName {
+		toIdx = slices.Index(l.toNames, path.DocumentName)
+	}
+
+	if toIdx < 0 {
+		return nil, nil, fmt.Errorf("there is no document named %s in %s", path.DocumentName, HumanReadableLocation(l.to.Location))
+	}
+
+	fromIdx, ok := l.pairs[toIdx]
+	if !ok {
+		return nil, nil, fmt.Errorf("there is no document named %s in %s", path.DocumentName, HumanReadableLocation(l.from.Location))
+	}
+
+	return l.from.Documents[fromIdx], l.to.Documents[toIdx], nil
+}
+
+// documentAt returns the document with the provided index, which starts with
+// zero like in document references of paths (`#1/foo`)
+func documentAt(inputFile InputFile, idx int) (*yamlv3.Node, error) {
+	if idx < 0 || idx >= len(inputFile.Documents) {
+		return nil, fmt.Errorf("there is no document #%d in %s", idx, HumanReadableLocation(inputFile.Location))
+	}
+
+	return inputFile.Documents[idx], nil
+}
@@ -21,6 +21,8 @@
 package ytbx
 
 import (
+	"fmt"
+
 	yamlv3 "go.yaml.in/yaml/v3"
 )
 
@@ -31,6 +33,10 @@ func Delete(node *yamlv3.Node, pathString string) (*yamlv3.Node, error) {
 		return nil, err
 	}
 
+	if err := rejectDocumentReference(pathString, path); err != nil {
+		return nil, err
+	}
+
 	switch node.Kind {
 	case yamlv3.DocumentNode:
 		return deletePath(node.Content[0], path)
@@ -40,7 +46,33 @@ func Delete(node *yamlv3.Node, pathString string) (*yamlv3.Node, error) {
 	}
 }
 
+// DeleteFromFile removes the section identified by the path from the document
+// of the input file that is referenced in the path (see `GrabFromFile`)
+func DeleteFromFile(inputFile InputFile, pathString string) (*yamlv3.Node, error) {
+	_, path, err := resolveDocumentPath(inputFile, pathString)
+	if err != nil {
+		return nil, err
+	}
+
+	return deleteFromDocument(inputFile, path)
+}
+
+// deleteFromDocument removes the section identified by the path from the
+// document of the input file with the document index of the path
+func deleteFromDocument(inputFile InputFile, path Path) (*yamlv3.Node, error) {
+	document, err := documentAt(inputFile, path.DocumentIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	return deletePath(documentRoot(document), path)
+}
+
 func deletePath(node *yamlv3.Node, path Path) (*yamlv3.Node, error) {
+	if len(path.PathElements) == 0 {
+		return nil, fmt.Errorf("failed to delete, the root of a document cannot be deleted")
+	}
+
 	parentPath := Path{
 		DocumentIdx:  path.DocumentIdx,
 		PathElements: path.PathElements[:len(path.PathElements)-1],
@@ -83,7 +115,7 @@ func deletePath(node *yamlv3.Node, path Path) (*yamlv3.Node, error) {
 		if lastPathElement.isSimpleListElement() {
 			deleteIdx = lastPathElement.Idx
 		} else {
-			deleteIdx, err = getIndexByIdentifierAndName(parent, lastPathElement.Key, lastPathElement.Name)
+			deleteIdx, err = getIndexByIdentifierAndName(parent, lastPathElement.identifierKeys(), lastPathElement.identifierNames())
 			if err != nil {
 				return nil, err
 			}
@@ -54,6 +54,16 @@ var _ = Describe("Delete from YAML", func() {
 			Expect(len(list.Content)).To(Equal(4))
 		})
 
+		It("should delete an entry in a referenced document of an input file", func() {
+			input, err := ytbx.LoadFile(assets("kubernetes", "list.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			node, err := ytbx.DeleteFromFile(input, "#1/data/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node.Value).To(BeEquivalentTo("bar"))
+			Expect(ytbx.IsPathInTree(input.Documents[1], "/data/foo")).To(BeFalse())
+		})
+
 		It("should delete an entry in a named entry list referenced by the path", func() {
 			node, err := ytbx.Delete(example, "/yaml/named-entry-list-using-name/name=C")
 			Expect(err).ToNot(HaveOccurred())
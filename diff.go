@@ -0,0 +1,422 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"fmt"
+	"slices"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// ChangeType is a custom type for the kinds of changes reported by `Diff`
+type ChangeType int
+
+// Supported change types are additions, removals, and modifications of values,
+// order changes of lists that still contain the same entries, and moves of
+// named-entry list entries to a different position in the list
+const (
+	Addition ChangeType = iota
+	Removal
+	Modification
+	OrderChange
+	Move
+)
+
+func (changeType ChangeType) String() string {
+	switch changeType {
+	case Addition:
+		return "addition"
+
+	case Removal:
+		return "removal"
+
+	case Modification:
+		return "modification"
+
+	case OrderChange:
+		return "order change"
+
+	case Move:
+		return "move"
+
+	default:
+		return "unknown change"
+	}
+}
+
+// Change describes one difference between two documents, where the path
+// refers to the changed section, and from and to are the old and new values.
+// From is nil for additions, and to is nil for removals.
+type Change struct {
+	Type ChangeType
+	Path Path
+	From *yamlv3.Node
+	To   *yamlv3.Node
+}
+
+func (change Change) String() string {
+	return fmt.Sprintf("%s %s in %s",
+		change.Type,
+		change.Path.ToGoPatchStyle(),
+		change.Path.RootDescription(),
+	)
+}
+
+// DiffOptions controls how `Diff` compares two input files
+type DiffOptions struct {
+	// Pairing defines which documents of the two files are compared
+	Pairing DocumentPairing
+
+	// Namers are used to name documents when pairing them by name, the
+	// default document namers are used if none are provided
+	Namers []DocumentNamer
+
+	// Equality controls when scalar values are considered unchanged
+	Equality EqualityOptions
+
+	// IgnoreOrderChanges omits order changes of lists and moves of
+	// named-entry list entries from the result
+	IgnoreOrderChanges bool
+}
+
+// Diff returns all changes between the documents of the two input files.
+// Documents are paired based on the options, documents without counterpart
+// are reported as a whole. Named-entry lists are compared entry by entry using
+// the identifier of the list, regardless of the position of the entries.
+func Diff(from InputFile, to InputFile, opts DiffOptions) []Change {
+	differ := &differ{opts: opts}
+
+	// Documents without counterpart are referred to by name as well, in case
+	// documents are paired by name
+	var fromNames, toNames []string
+	var pairs []DocumentPair
+	switch opts.Pairing {
+	case PairByName:
+		fromNames = NameDocuments(from, opts.Namers...).Names
+		toNames = NameDocuments(to, opts.Namers...).Names
+		pairs = pairNamedDocuments(fromNames, toNames)
+
+	default:
+		pairs = pairDocuments(from, to, CompareOptions{Pairing: opts.Pairing})
+	}
+
+	pairedFrom, pairedTo := map[int]struct{}{}, map[int]struct{}{}
+	for _, pair := range pairs {
+		pairedFrom[pair.FromIdx] = struct{}{}
+		pairedTo[pair.ToIdx] = struct{}{}
+	}
+
+	for idx, document := range from.Documents {
+		if _, ok := pairedFrom[idx]; !ok {
+			differ.report(Removal, unpairedDocumentPath(&from, idx, fromNames), documentRoot(document), nil)
+		}
+	}
+
+	for _, pair := range pairs {
+		root := Path{Root: &to, DocumentIdx: pair.ToIdx}
+		if opts.Pairing == PairByName {
+			root.DocumentName = pair.Name
+		}
+
+		differ.diff(root, documentRoot(from.Documents[pair.FromIdx]), documentRoot(to.Documents[pair.ToIdx]))
+	}
+
+	for idx, document := range to.Documents {
+		if _, ok := pairedTo[idx]; !ok {
+			differ.report(Addition, unpairedDocumentPath(&to, idx, toNames), nil, documentRoot(document))
+		}
+	}
+
+	return differ.changes
+}
+
+func unpairedDocumentPath(inputFile *InputFile, idx int, names []string) Path {
+	path := Path{Root: inputFile, DocumentIdx: idx}
+	if idx < len(names) {
+		path.DocumentName = names[idx]
+	}
+
+	return path
+}
+
+type differ struct {
+	opts    DiffOptions
+	changes []Change
+}
+
+func (d *differ) report(changeType ChangeType, path Path, from *yamlv3.Node, to *yamlv3.Node) {
+	if d.opts.IgnoreOrderChanges && (changeType == OrderChange || changeType == Move) {
+		return
+	}
+
+	d.changes = append(d.changes, Change{Type: changeType, Path: path, From: from, To: to})
+}
+
+func (d *differ) diff(path Path, from *yamlv3.Node, to *yamlv3.Node) {
+	if from.Kind == yamlv3.AliasNode && from.Alias != nil {
+		from = from.Alias
+	}
+
+	if to.Kind == yamlv3.AliasNode && to.Alias != nil {
+		to = to.Alias
+	}
+
+	switch {
+	case from.Kind != to.Kind || (from.Kind != yamlv3.ScalarNode && from.ShortTag() != to.ShortTag()):
+		d.report(Modification, path, from, to)
+
+	case from.Kind == yamlv3.MappingNode:
+		d.diffMaps(path, from, to)
+
+	case from.Kind == yamlv3.SequenceNode:
+		d.diffLists(path, from, to)
+
+	case !NodesEqual(from, to, d.opts.Equality):
+		d.report(Modification, path, from, to)
+	}
+}
+
+func (d *differ) diffMaps(path Path, from *yamlv3.Node, to *yamlv3.Node) {
+	fromValues, toValues := valuesByKey(from), valuesByKey(to)
+
+	for i := 0; i < len(from.Content); i += 2 {
+		key := from.Content[i].Value
+		if toValue, ok := toValues[key]; ok {
+			d.diff(NewPathWithNamedElement(path, key), from.Content[i+1], toValue)
+		} else {
+			d.report(Removal, NewPathWithNamedElement(path, key), from.Content[i+1], nil)
+		}
+	}
+
+	for i := 0; i < len(to.Content); i += 2 {
+		key := to.Content[i].Value
+		if _, ok := fromValues[key]; !ok {
+			d.report(Addition, NewPathWithNamedElement(path, key), nil, to.Content[i+1])
+		}
+	}
+}
+
+// valuesByKey returns the values of a map by key, where the first occurrence
+// of a duplicate key is used like in `getValueByKey`
+func valuesByKey(mappingNode *yamlv3.Node) map[string]*yamlv3.Node {
+	result := make(map[string]*yamlv3.Node, len(mappingNode.Content)/2)
+	for i := 0; i+1 < len(mappingNode.Content); i += 2 {
+		if _, ok := result[mappingNode.Content[i].Value]; !ok {
+			result[mappingNode.Content[i].Value] = mappingNode.Content[i+1]
+		}
+	}
+
+	return result
+}
+
+func (d *differ) diffLists(path Path, from *yamlv3.Node, to *yamlv3.Node) {
+	fromIdentifier := DefaultIdentifierResolver.Identifier(path, from)
+	toIdentifier := DefaultIdentifierResolver.Identifier(path, to)
+
+	switch {
+	case fromIdentifier != nil && slices.Equal(fromIdentifier, toIdentifier):
+		d.diffNamedLists(path, from, to, fromIdentifier)
+
+	case isScalarList(from) && isScalarList(to):
+		d.diffScalarLists(path, from, to)
+
+	default:
+		// Lists of complex entries without identifier are compared by index
+		for i := 0; i < len(from.Content) || i < len(to.Content); i++ {
+			entryPath := NewPathWithIndexedListElement(path, i)
+			switch {
+			case i >= len(to.Content):
+				d.report(Removal, entryPath, from.Content[i], nil)
+
+			case i >= len(from.Content):
+				d.report(Addition, entryPath, nil, to.Content[i])
+
+			default:
+				d.diff(entryPath, from.Content[i], to.Content[i])
+			}
+		}
+	}
+}
+
+func (d *differ) diffNamedLists(path Path, from *yamlv3.Node, to *yamlv3.Node, identifier []string) {
+	fromNames, toNames := entryNames(from, identifier), entryNames(to, identifier)
+	fromLookup, toLookup := lookupMap(fromNames), lookupMap(toNames)
+
+	var commonFrom, commonTo []string
+	for _, name := range fromNames {
+		if _, ok := toLookup[name]; ok {
+			commonFrom = append(commonFrom, name)
+		}
+	}
+
+	for _, name := range toNames {
+		if _, ok := fromLookup[name]; ok {
+			commonTo = append(commonTo, name)
+		}
+	}
+
+	// Entries that are not part of the longest sequence of entries that kept
+	// their relative order are the ones that moved
+	stable := lookupMap(longestCommonSubsequence(commonFrom, commonTo, func(a, b string) bool { return a == b }))
+
+	for idx, name := range fromNames {
+		entryPath := NewPathWithPathElement(path, namedListEntryElement(from.Content[idx], identifier))
+		if _, ok := toLookup[name]; !ok {
+			d.report(Removal, entryPath, from.Content[idx], nil)
+		}
+	}
+
+	for idx, name := range toNames {
+		entryPath := NewPathWithPathElement(path, namedListEntryElement(to.Content[idx], identifier))
+		fromIdx, ok := fromLookup[name]
+		if !ok {
+			d.report(Addition, entryPath, nil, to.Content[idx])
+			continue
+		}
+
+		if _, ok := stable[name]; !ok {
+			d.report(Move, entryPath, from.Content[fromIdx], to.Content[idx])
+		}
+
+		d.diff(entryPath, from.Content[fromIdx], to.Content[idx])
+	}
+}
+
+func (d *differ) diffScalarLists(path Path, from *yamlv3.Node, to *yamlv3.Node) {
+	equal := func(a, b *yamlv3.Node) bool { return NodesEqual(a, b, d.opts.Equality) }
+
+	common := longestCommonSubsequence(from.Content, to.Content, equal)
+	if len(common) == len(from.Content) && len(common) == len(to.Content) {
+		return
+	}
+
+	if len(from.Content) == len(to.Content) && isPermutation(from.Content, to.Content, equal) {
+		d.report(OrderChange, path, from, to)
+		return
+	}
+
+	// Map the entries of the common sequence to their positions in both lists,
+	// entries that are not part of it were removed or added
+	inCommon := func(list []*yamlv3.Node) map[int]struct{} {
+		result, next := map[int]struct{}{}, 0
+		for idx, entry := range list {
+			if next < len(common) && equal(entry, common[next]) {
+				result[idx] = struct{}{}
+				next++
+			}
+		}
+
+		return result
+	}
+
+	fromCommon, toCommon := inCommon(from.Content), inCommon(to.Content)
+
+	for idx, entry := range from.Content {
+		if _, ok := fromCommon[idx]; !ok {
+			d.report(Removal, NewPathWithIndexedListElement(path, idx), entry, nil)
+		}
+	}
+
+	for idx, entry := range to.Content {
+		if _, ok := toCommon[idx]; !ok {
+			d.report(Addition, NewPathWithIndexedListElement(path, idx), nil, entry)
+		}
+	}
+}
+
+// entryNames returns the GoPatch style representation of the path element of
+// each named-entry list entry, e.g. `name=web`, which identifies the entry
+func entryNames(sequenceNode *yamlv3.Node, keys []string) []string {
+	names := make([]string, len(sequenceNode.Content))
+	for idx, entry := range sequenceNode.Content {
+		element := namedListEntryElement(entry, keys)
+		names[idx] = formatIdentifierAndName(element.identifierKeys(), element.identifierNames())
+	}
+
+	return names
+}
+
+func isScalarList(sequenceNode *yamlv3.Node) bool {
+	for _, entry := range sequenceNode.Content {
+		if entry.Kind != yamlv3.ScalarNode {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isPermutation(a []*yamlv3.Node, b []*yamlv3.Node, equal func(a, b *yamlv3.Node) bool) bool {
+	used := make([]bool, len(b))
+	for _, entryA := range a {
+		found := false
+		for idx, entryB := range b {
+			if !used[idx] && equal(entryA, entryB) {
+				used[idx], found = true, true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// longestCommonSubsequence returns the longest sequence of entries of list a
+// that are also in list b in the same relative order
+func longestCommonSubsequence[T any](a []T, b []T, equal func(a, b T) bool) []T {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if equal(a[i], b[j]) {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var result []T
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case equal(a[i], b[j]):
+			result = append(result, a[i])
+			i++
+			j++
+
+		case lengths[i+1][j] > lengths[i][j+1]:
+			i++
+
+		default:
+			j++
+		}
+	}
+
+	return result
+}
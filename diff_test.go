@@ -0,0 +1,162 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+)
+
+var _ = Describe("Diff", func() {
+	summary := func(changes []Change) []string {
+		var result []string
+		for _, change := range changes {
+			result = append(result, fmt.Sprintf("%s %s", change.Type, change.Path.ToGoPatchStyle()))
+		}
+
+		return result
+	}
+
+	Context("Comparing two input files", func() {
+		It("should report no changes for semantically identical documents", func() {
+			Expect(Diff(
+				inputFile(`{ a: "foo", b: [ 1, 2 ], c: { d: ~ } }`),
+				inputFile(`{ c: { d: null }, b: [ 1, 2 ], a: foo }`),
+				DiffOptions{},
+			)).To(BeEmpty())
+		})
+
+		It("should report additions, removals, and modifications", func() {
+			changes := Diff(
+				inputFile(`{ name: web, replicas: 1, debug: true, spec: { image: nginx } }`),
+				inputFile(`{ name: web, replicas: 2, spec: { image: nginx, port: 80 }, labels: {} }`),
+				DiffOptions{},
+			)
+
+			Expect(summary(changes)).To(Equal([]string{
+				"modification /replicas",
+				"removal /debug",
+				"addition /spec/port",
+				"addition /labels",
+			}))
+
+			Expect(changes[0].From.Value).To(Equal("1"))
+			Expect(changes[0].To.Value).To(Equal("2"))
+			Expect(changes[1].To).To(BeNil())
+			Expect(changes[2].From).To(BeNil())
+		})
+
+		It("should report a modification for changed types", func() {
+			Expect(summary(Diff(
+				inputFile(`{ a: { b: 1 }, c: "1" }`),
+				inputFile(`{ a: [ b ], c: 1 }`),
+				DiffOptions{},
+			))).To(Equal([]string{"modification /a", "modification /c"}))
+		})
+
+		It("should optionally normalize scalars", func() {
+			Expect(Diff(
+				inputFile(`{ a: 0x1F, b: yes }`),
+				inputFile(`{ a: 31, b: true }`),
+				DiffOptions{Equality: EqualityOptions{NormalizeNumbers: true, NormalizeBooleans: true}},
+			)).To(BeEmpty())
+		})
+
+		It("should match named-entry list entries by their identifier", func() {
+			changes := Diff(
+				inputFile(`{ jobs: [ { name: a, x: 1 }, { name: b, x: 1 }, { name: c, x: 1 } ] }`),
+				inputFile(`{ jobs: [ { name: c, x: 1 }, { name: a, x: 2 }, { name: d, x: 1 } ] }`),
+				DiffOptions{},
+			)
+
+			Expect(summary(changes)).To(Equal([]string{
+				"removal /jobs/name=b",
+				"move /jobs/name=c",
+				"modification /jobs/name=a/x",
+				"addition /jobs/name=d",
+			}))
+		})
+
+		It("should report order changes and entries of simple lists", func() {
+			Expect(summary(Diff(
+				inputFile(`{ a: [ x, y, z ], b: [ x, y, z ] }`),
+				inputFile(`{ a: [ z, y, x ], b: [ x, z, w ] }`),
+				DiffOptions{},
+			))).To(Equal([]string{
+				"order change /a",
+				"removal /b/1",
+				"addition /b/2",
+			}))
+		})
+
+		It("should optionally ignore order changes", func() {
+			Expect(Diff(
+				inputFile(`{ a: [ x, y ], jobs: [ { name: a }, { name: b } ] }`),
+				inputFile(`{ a: [ y, x ], jobs: [ { name: b }, { name: a } ] }`),
+				DiffOptions{IgnoreOrderChanges: true},
+			)).To(BeEmpty())
+		})
+
+		It("should compare lists without identifier by index", func() {
+			Expect(summary(Diff(
+				inputFile(`{ a: [ { x: 1 }, { x: 2 } ] }`),
+				inputFile(`{ a: [ { x: 1 }, { x: 3 }, { x: 4 } ] }`),
+				DiffOptions{},
+			))).To(Equal([]string{"modification /a/1/x", "addition /a/2"}))
+		})
+
+		It("should pair documents by name", func() {
+			from, err := LoadFile(assets("testbed", "multi_a.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			to, err := LoadFile(assets("testbed", "multi_b.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			changes := Diff(from, to, DiffOptions{Pairing: PairByName})
+			Expect(summary(changes)).To(Equal([]string{"modification /spec/type", "modification /data/level"}))
+			Expect(changes[0].Path.DocumentName).To(Equal("Service/web"))
+			Expect(changes[0].String()).To(Equal("modification /spec/type in Service/web"))
+		})
+
+		It("should report documents without counterpart as a whole", func() {
+			changes := Diff(inputFile(`{ a: 1 }`, `{ b: 2 }`), inputFile(`{ a: 1 }`), DiffOptions{})
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].Type).To(Equal(Removal))
+			Expect(changes[0].Path.DocumentIdx).To(Equal(1))
+		})
+
+		It("should refer to documents without counterpart by name when pairing by name", func() {
+			changes := Diff(
+				inputFile(`{ apiVersion: v1, kind: ConfigMap, metadata: { name: a } }`, `{ apiVersion: v1, kind: Secret, metadata: { name: b } }`),
+				inputFile(`{ apiVersion: v1, kind: ConfigMap, metadata: { name: a } }`, `{ apiVersion: v1, kind: Service, metadata: { name: c } }`),
+				DiffOptions{Pairing: PairByName},
+			)
+
+			Expect(summary(changes)).To(Equal([]string{"removal /", "addition /"}))
+			Expect(changes[0].Path.DocumentName).To(Equal("Secret/b"))
+			Expect(changes[1].Path.DocumentName).To(Equal("Service/c"))
+		})
+	})
+})
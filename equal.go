@@ -0,0 +1,175 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"strconv"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// EqualityOptions controls which representations of values `NodesEqual`
+// considers to be the same
+type EqualityOptions struct {
+	// NormalizeNumbers makes numbers equal if they have the same value,
+	// regardless of notation or type, for example `0x1F`, `31`, and `31.0`
+	NormalizeNumbers bool
+
+	// NormalizeBooleans makes booleans equal regardless of notation, which
+	// includes the YAML 1.1 booleans, for example `True`, `true`, and `yes`
+	NormalizeBooleans bool
+}
+
+// NodesEqual returns whether the two YAML trees are semantically equal, which
+// means that positions, styles, comments, anchors, and the order of map keys
+// are ignored, and aliases and merge keys (`<<`) are resolved. Scalars are
+// equal if they have the same resolved type and value, so that `"foo"` and
+// `foo` are equal, while `"42"` and `42` are not. The order of list entries is
+// significant.
+func NodesEqual(a *yamlv3.Node, b *yamlv3.Node, opts EqualityOptions) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if a.Kind == yamlv3.AliasNode {
+		return NodesEqual(a.Alias, b, opts)
+	}
+
+	if b.Kind == yamlv3.AliasNode {
+		return NodesEqual(a, b.Alias, opts)
+	}
+
+	if a.Kind == yamlv3.DocumentNode || b.Kind == yamlv3.DocumentNode {
+		return NodesEqual(documentRoot(a), documentRoot(b), opts)
+	}
+
+	if a.Kind != b.Kind {
+		return false
+	}
+
+	switch a.Kind {
+	case yamlv3.MappingNode:
+		return mappingsEqual(a, b, opts)
+
+	case yamlv3.SequenceNode:
+		if a.ShortTag() != b.ShortTag() || len(a.Content) != len(b.Content) {
+			return false
+		}
+
+		for i := range a.Content {
+			if !NodesEqual(a.Content[i], b.Content[i], opts) {
+				return false
+			}
+		}
+
+		return true
+
+	default:
+		return scalarsEqual(a, b, opts)
+	}
+}
+
+func scalarsEqual(a *yamlv3.Node, b *yamlv3.Node, opts EqualityOptions) bool {
+	if opts.NormalizeNumbers {
+		numberA, isNumberA := numberOf(a)
+		numberB, isNumberB := numberOf(b)
+		if isNumberA && isNumberB {
+			return numberA == numberB
+		}
+	}
+
+	if opts.NormalizeBooleans {
+		tagA, valueA := canonicalScalar(a)
+		tagB, valueB := canonicalScalar(b)
+		if tagA == tagBool && tagB == tagBool {
+			return valueA == valueB
+		}
+	}
+
+	// All notations of null refer to the same value, e.g. `~` and `null`
+	if a.ShortTag() == tagNull && b.ShortTag() == tagNull {
+		return true
+	}
+
+	return a.ShortTag() == b.ShortTag() && a.Value == b.Value
+}
+
+// mappingsEqual returns whether both maps have equal keys with equal values,
+// where each key of one map has to be matched by a different key of the other
+func mappingsEqual(a *yamlv3.Node, b *yamlv3.Node, opts EqualityOptions) bool {
+	contentA, contentB := mergedContent(a), mergedContent(b)
+	if a.ShortTag() != b.ShortTag() || len(contentA) != len(contentB) {
+		return false
+	}
+
+	// Scalar keys are looked up by their normalized value, complex keys have
+	// to be compared one by one
+	lookup, complexKeys := map[string][]int{}, []int{}
+	for i := 0; i < len(contentB); i += 2 {
+		if id, ok := equalityKeyID(contentB[i], opts); ok {
+			lookup[id] = append(lookup[id], i)
+		} else {
+			complexKeys = append(complexKeys, i)
+		}
+	}
+
+	used := make([]bool, len(contentB))
+	for i := 0; i < len(contentA); i += 2 {
+		candidates := complexKeys
+		if id, ok := equalityKeyID(contentA[i], opts); ok {
+			candidates = lookup[id]
+		}
+
+		match := -1
+		for _, j := range candidates {
+			if !used[j] && NodesEqual(contentA[i], contentB[j], opts) && NodesEqual(contentA[i+1], contentB[j+1], opts) {
+				match = j
+				break
+			}
+		}
+
+		if match < 0 {
+			return false
+		}
+
+		used[match] = true
+	}
+
+	return true
+}
+
+// equalityKeyID returns a string for scalar keys that is the same for all keys
+// that may be equal with the provided options
+func equalityKeyID(key *yamlv3.Node, opts EqualityOptions) (string, bool) {
+	key = resolveAlias(key)
+	if key.Kind != yamlv3.ScalarNode {
+		return "", false
+	}
+
+	if opts.NormalizeNumbers {
+		if number, ok := numberOf(key); ok {
+			return "number:" + strconv.FormatFloat(number, 'g', -1, 64), true
+		}
+	}
+
+	tag, value := canonicalScalar(key)
+	return tag + ":" + value, true
+}
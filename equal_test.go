@@ -0,0 +1,78 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+)
+
+var _ = Describe("Semantic equality", func() {
+	Context("Comparing YAML trees", func() {
+		DescribeTable("should consider semantically identical trees equal",
+			func(a string, b string) {
+				Expect(NodesEqual(yml(a), yml(b), EqualityOptions{})).To(BeTrue())
+			},
+
+			Entry("different styles", `{ a: "foo", b: 'bar' }`, `{ a: foo, b: bar }`),
+			Entry("different key order", `{ a: 1, b: 2 }`, `{ b: 2, a: 1 }`),
+			Entry("different positions and comments", "---\n# comment\na: 1 # one\n", `{ a: 1 }`),
+			Entry("aliases", `{ x: &x { a: 1 }, y: *x }`, `{ x: { a: 1 }, y: { a: 1 } }`),
+			Entry("block and flow lists", "---\n- a\n- b\n", `[ a, b ]`),
+			Entry("null notations", `{ a: ~, b: null }`, `{ a: null, b: }`),
+			Entry("merge keys", `{ x: &x { a: 1 }, y: { <<: *x, b: 2 } }`, `{ x: { a: 1 }, y: { a: 1, b: 2 } }`),
+		)
+
+		DescribeTable("should consider different trees not equal",
+			func(a string, b string) {
+				Expect(NodesEqual(yml(a), yml(b), EqualityOptions{})).To(BeFalse())
+			},
+
+			Entry("different values", `{ a: 1 }`, `{ a: 2 }`),
+			Entry("different types", `{ a: "1" }`, `{ a: 1 }`),
+			Entry("different keys", `{ a: 1 }`, `{ b: 1 }`),
+			Entry("additional keys", `{ a: 1 }`, `{ a: 1, b: 2 }`),
+			Entry("duplicate keys", `{ a: 1, a: 1 }`, `{ a: 1, b: 2 }`),
+			Entry("different list order", `[ a, b ]`, `[ b, a ]`),
+			Entry("different number notation", `0x1F`, `31`),
+			Entry("different boolean notation", `yes`, `true`),
+		)
+
+		It("should optionally normalize numbers", func() {
+			opts := EqualityOptions{NormalizeNumbers: true}
+			Expect(NodesEqual(yml(`0x1F`), yml(`31`), opts)).To(BeTrue())
+			Expect(NodesEqual(yml(`31.0`), yml(`31`), opts)).To(BeTrue())
+			Expect(NodesEqual(yml(`{ a: 1e3 }`), yml(`{ a: 1000 }`), opts)).To(BeTrue())
+			Expect(NodesEqual(yml(`"31"`), yml(`31`), opts)).To(BeFalse())
+			Expect(NodesEqual(yml(`{ 31.0: a, 0x1F: b }`), yml(`{ 31: b, 31: a }`), opts)).To(BeTrue())
+		})
+
+		It("should optionally normalize booleans", func() {
+			opts := EqualityOptions{NormalizeBooleans: true}
+			Expect(NodesEqual(yml(`yes`), yml(`true`), opts)).To(BeTrue())
+			Expect(NodesEqual(yml(`True`), yml(`on`), opts)).To(BeTrue())
+			Expect(NodesEqual(yml(`off`), yml(`true`), opts)).To(BeFalse())
+			Expect(NodesEqual(yml(`"yes"`), yml(`true`), opts)).To(BeFalse())
+		})
+	})
+})
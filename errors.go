@@ -22,7 +22,10 @@ package ytbx
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+
+	yamlv3 "go.yaml.in/yaml/v3"
 )
 
 // KeyNotFoundInMapError represents an error when a key in a map was expected,
@@ -30,12 +33,193 @@ import (
 type KeyNotFoundInMapError struct {
 	MissingKey    string
 	AvailableKeys []string
+
+	// Suggestions lists similar keys, in case the error occurred while
+	// traversing a YAML tree
+	Suggestions []string
+
+	// Path, Line, and Column refer to the map in which the key is missing, in
+	// case the error occurred while traversing a YAML tree
+	Path   Path
+	Line   int
+	Column int
 }
 
 func (e *KeyNotFoundInMapError) Error() string {
-	return fmt.Sprintf("no key '%s' found in map, available keys: %s",
+	return fmt.Sprintf("no key '%s' found in map, available keys: %s%s",
 		e.MissingKey,
-		strings.Join(e.AvailableKeys, ", "))
+		strings.Join(e.AvailableKeys, ", "),
+		didYouMean(e.Suggestions))
+}
+
+// TypeMismatchError represents the situation where a section of the YAML tree
+// is not of the type that is required. This is either the case when a path
+// cannot be followed, for example an index based path element for a map, or
+// when the value referenced by a path cannot be used as the expected type, for
+// example by `GrabInt`.
+type TypeMismatchError struct {
+	Path         Path
+	Line         int
+	Column       int
+	ExpectedType string
+	ActualType   string
+
+	// Available lists the keys of a map, or the names of a named-entry list,
+	// which could have been used at this path instead
+	Available []string
+
+	// Cause is the underlying error in case the value could not be decoded
+	Cause error
+
+	// value is set if the path could be followed, but the referenced value
+	// does not have the expected type
+	value bool
+}
+
+func newTypeMismatchError(path Path, node *yamlv3.Node, expectedType string) *TypeMismatchError {
+	var available []string
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		available = listKeys(node)
+
+	case yamlv3.SequenceNode:
+		if keys := DefaultIdentifierResolver.Identifier(path, node); keys != nil {
+			for _, entry := range node.Content {
+				if names, err := getNameByIdentifier(entry, keys); err == nil {
+					available = append(available, formatIdentifierAndName(keys, names))
+				}
+			}
+		}
+	}
+
+	return &TypeMismatchError{
+		Path:         path,
+		Line:         node.Line,
+		Column:       node.Column,
+		ExpectedType: expectedType,
+		ActualType:   TypeOf(node).String(),
+		Available:    available,
+	}
+}
+
+func newValueTypeMismatchError(path Path, expectedType string, node *yamlv3.Node, cause error) *TypeMismatchError {
+	return &TypeMismatchError{
+		Path:         path,
+		Line:         node.Line,
+		Column:       node.Column,
+		ExpectedType: expectedType,
+		ActualType:   TypeOf(node).String(),
+		Cause:        cause,
+		value:        true,
+	}
+}
+
+func (e *TypeMismatchError) Error() string {
+	switch {
+	case !e.value:
+		return fmt.Sprintf("failed to traverse tree, expected %s but found type %s at %s",
+			e.ExpectedType,
+			e.ActualType,
+			e.Path.ToGoPatchStyle())
+
+	case e.Cause != nil:
+		return fmt.Sprintf("value at %s cannot be used as %s (found type %s): %v",
+			e.Path.ToGoPatchStyle(),
+			e.ExpectedType,
+			e.ActualType,
+			e.Cause)
+
+	default:
+		return fmt.Sprintf("value at %s cannot be used as %s, found type %s",
+			e.Path.ToGoPatchStyle(),
+			e.ExpectedType,
+			e.ActualType)
+	}
+}
+
+func (e *TypeMismatchError) Unwrap() error {
+	return e.Cause
+}
+
+// IndexOutOfRangeError represents the situation where a path refers to a list
+// entry by an index that is not in the range of the list.
+type IndexOutOfRangeError struct {
+	Path   Path
+	Line   int
+	Column int
+	Index  int
+	Length int
+}
+
+func (e *IndexOutOfRangeError) Error() string {
+	return fmt.Sprintf("failed to traverse tree, provided list index %d is not in range: 0..%d",
+		e.Index,
+		e.Length-1)
+}
+
+// NamedEntryNotFoundError represents the situation where a path refers to a
+// named-entry list entry that does not exist in the list. In case of a
+// composite identifier, the identifier keys and the names are comma separated.
+type NamedEntryNotFoundError struct {
+	Path           Path
+	Line           int
+	Column         int
+	Identifier     string
+	Name           string
+	AvailableNames []string
+
+	// Suggestions lists similar names, in case the error occurred while
+	// traversing a YAML tree
+	Suggestions []string
+
+	keys  []string
+	names []string
+}
+
+func newNamedEntryNotFoundError(keys []string, names []string, available []string) *NamedEntryNotFoundError {
+	return &NamedEntryNotFoundError{
+		Identifier:     strings.Join(keys, ","),
+		Name:           strings.Join(names, ","),
+		AvailableNames: available,
+		keys:           keys,
+		names:          names,
+	}
+}
+
+func (e *NamedEntryNotFoundError) Error() string {
+	keys, names := e.keys, e.names
+	if keys == nil {
+		keys, names = []string{e.Identifier}, []string{e.Name}
+	}
+
+	suggestions := make([]string, len(e.Suggestions))
+	for i, suggestion := range e.Suggestions {
+		suggestions[i] = formatIdentifierAndName(keys, strings.SplitN(suggestion, ",", len(keys)))
+	}
+
+	return fmt.Sprintf("there is no entry %s in the list%s",
+		formatIdentifierAndName(keys, names),
+		didYouMean(suggestions))
+}
+
+// AmbiguousPathError represents the situation where a path refers to more than
+// one section of a YAML tree, because of duplicate keys in a map or duplicate
+// identifiers in a named-entry list.
+type AmbiguousPathError struct {
+	Path  Path
+	Lines []int
+}
+
+func (e *AmbiguousPathError) Error() string {
+	lines := make([]string, len(e.Lines))
+	for i, line := range e.Lines {
+		lines[i] = strconv.Itoa(line)
+	}
+
+	return fmt.Sprintf("path %s is ambiguous, it matches %d entries in lines %s",
+		e.Path.ToGoPatchStyle(),
+		len(e.Lines),
+		strings.Join(lines, ", "))
 }
 
 // NoNamedEntryListError represents the situation where a list was expected to
@@ -62,11 +246,13 @@ type InvalidPathString struct {
 	Style       PathStyle
 	PathString  string
 	Explanation string
+	Suggestions []string
 }
 
 func (e *InvalidPathString) Error() string {
-	return fmt.Sprintf("invalid %v style path %s, %s",
+	return fmt.Sprintf("invalid %v style path %s, %s%s",
 		e.Style,
 		e.PathString,
-		e.Explanation)
+		e.Explanation,
+		didYouMean(e.Suggestions))
 }
@@ -21,6 +21,7 @@
 package ytbx
 
 import (
+	"errors"
 	"fmt"
 
 	yamlv3 "go.yaml.in/yaml/v3"
@@ -34,6 +35,10 @@ func Grab(node *yamlv3.Node, pathString string) (*yamlv3.Node, error) {
 		return nil, err
 	}
 
+	if err := rejectDocumentReference(pathString, path); err != nil {
+		return nil, err
+	}
+
 	switch node.Kind {
 	case yamlv3.DocumentNode:
 		return grabByPath(node.Content[0], path)
@@ -43,7 +48,40 @@ func Grab(node *yamlv3.Node, pathString string) (*yamlv3.Node, error) {
 	}
 }
 
+// GrabFromFile gets the value from the document of the input file that is
+// referenced in the path, for example `#1/spec/replicas` for the second
+// document, or `#web/spec/replicas` for the document named `web`. Paths
+// without document reference refer to the first document.
+func GrabFromFile(inputFile InputFile, pathString string) (*yamlv3.Node, error) {
+	document, path, err := resolveDocumentPath(inputFile, pathString)
+	if err != nil {
+		return nil, err
+	}
+
+	return grabByPath(documentRoot(document), path)
+}
+
+// GrabStrict works like `Grab`, but fails with an `AmbiguousPathError` if a map
+// key or named-entry list entry referenced by the path is not unique and could
+// therefore refer to more than one section of the YAML tree
+func GrabStrict(node *yamlv3.Node, pathString string) (*yamlv3.Node, error) {
+	path, err := ParsePathString(pathString, node)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rejectDocumentReference(pathString, path); err != nil {
+		return nil, err
+	}
+
+	return grabByPathWithMode(documentRoot(node), path, true)
+}
+
 func grabByPath(node *yamlv3.Node, path Path) (*yamlv3.Node, error) {
+	return grabByPathWithMode(node, path, false)
+}
+
+func grabByPathWithMode(node *yamlv3.Node, path Path, strict bool) (*yamlv3.Node, error) {
 	pointer := node
 	pointerPath := Path{DocumentIdx: path.DocumentIdx}
 
@@ -52,58 +90,76 @@ func grabByPath(node *yamlv3.Node, path Path) (*yamlv3.Node, error) {
 		// Key/Value Map, where the element name is the key for the map
 		case element.isMapElement():
 			if pointer.Kind != yamlv3.MappingNode {
-				return nil,
-					fmt.Errorf("failed to traverse tree, expected %s but found type %s at %s",
-						typeMap,
-						GetType(pointer),
-						pointerPath.ToGoPatchStyle(),
-					)
+				return nil, newTypeMismatchError(pointerPath, pointer, typeMap)
 			}
 
 			entry, err := getValueByKey(pointer, element.Name)
 			if err != nil {
+				var keyNotFoundErr *KeyNotFoundInMapError
+				if errors.As(err, &keyNotFoundErr) {
+					keyNotFoundErr.Path = pointerPath
+					keyNotFoundErr.Line, keyNotFoundErr.Column = pointer.Line, pointer.Column
+					keyNotFoundErr.Suggestions = suggest(keyNotFoundErr.MissingKey, keyNotFoundErr.AvailableKeys)
+				}
+
 				return nil, err
 			}
 
+			if strict {
+				if lines := keyLines(pointer, element.Name); len(lines) > 1 {
+					return nil, &AmbiguousPathError{
+						Path:  NewPathWithPathElement(pointerPath, element),
+						Lines: lines,
+					}
+				}
+			}
+
 			pointer = entry
 
 		// Complex List, where each list entry is a Key/Value map and the entry is
 		// identified by name using an identifier (e.g. name, key, or id)
 		case element.isComplexListElement():
 			if pointer.Kind != yamlv3.SequenceNode {
-				return nil,
-					fmt.Errorf("failed to traverse tree, expected %s but found type %s at %s",
-						typeComplexList,
-						GetType(pointer),
-						pointerPath.ToGoPatchStyle(),
-					)
+				return nil, newTypeMismatchError(pointerPath, pointer, typeComplexList)
 			}
 
-			entry, err := getEntryByIdentifierAndName(pointer, element.Key, element.Name)
+			entry, err := getEntryByIdentifierAndName(pointer, element.identifierKeys(), element.identifierNames())
 			if err != nil {
+				var notFoundErr *NamedEntryNotFoundError
+				if errors.As(err, &notFoundErr) {
+					notFoundErr.Path = pointerPath
+					notFoundErr.Line, notFoundErr.Column = pointer.Line, pointer.Column
+					notFoundErr.Suggestions = suggest(notFoundErr.Name, notFoundErr.AvailableNames)
+				}
+
 				return nil, err
 			}
 
+			if strict {
+				if lines := entryLines(pointer, element.identifierKeys(), element.identifierNames()); len(lines) > 1 {
+					return nil, &AmbiguousPathError{
+						Path:  NewPathWithPathElement(pointerPath, element),
+						Lines: lines,
+					}
+				}
+			}
+
 			pointer = entry
 
 		// Simple List (identified by index)
 		case element.isSimpleListElement():
 			if pointer.Kind != yamlv3.SequenceNode {
-				return nil,
-					fmt.Errorf("failed to traverse tree, expected %s but found type %s at %s",
-						typeSimpleList,
-						GetType(pointer),
-						pointerPath.ToGoPatchStyle(),
-					)
+				return nil, newTypeMismatchError(pointerPath, pointer, typeSimpleList)
 			}
 
 			if element.Idx < 0 || element.Idx >= len(pointer.Content) {
-				return nil,
-					fmt.Errorf("failed to traverse tree, provided %s index %d is not in range: 0..%d",
-						typeSimpleList,
-						element.Idx,
-						len(pointer.Content)-1,
-					)
+				return nil, &IndexOutOfRangeError{
+					Path:   pointerPath,
+					Line:   pointer.Line,
+					Column: pointer.Column,
+					Index:  element.Idx,
+					Length: len(pointer.Content),
+				}
 			}
 
 			pointer = pointer.Content[element.Idx]
@@ -21,10 +21,14 @@
 package ytbx_test
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/gonvenience/ytbx"
+
+	yamlv3 "go.yaml.in/yaml/v3"
 )
 
 var _ = Describe("getting stuff test cases", func() {
@@ -61,15 +65,150 @@ var _ = Describe("getting stuff test cases", func() {
 			Expect(grabError(example, "/yaml/0")).To(BeEquivalentTo("failed to traverse tree, expected list but found type map at /yaml"))
 			Expect(grabError(example, "/yaml/simple-list/foobar")).To(BeEquivalentTo("failed to traverse tree, expected map but found type list at /yaml/simple-list"))
 			Expect(grabError(example, "/yaml/map/foobar=0")).To(BeEquivalentTo("failed to traverse tree, expected complex-list but found type map at /yaml/map"))
+			Expect(grabError(example, "/yaml/map/intA/foobar")).To(BeEquivalentTo("failed to traverse tree, expected map but found type int at /yaml/map/intA"))
 			Expect(grabError(example, "/yaml/named-entry-list-using-id/id=0")).To(BeEquivalentTo("there is no entry id=0 in the list"))
 		})
 	})
 
+	Context("Suggestions for typos in paths", func() {
+		It("should suggest keys that differ in separators or case", func() {
+			example := yml(assets("bosh-yaml", "manifest.yml"))
+			Expect(grabError(example, "/instance-groups")).To(HaveSuffix(", did you mean 'instance_groups'?"))
+			Expect(grabError(example, "/Releases")).To(HaveSuffix(", did you mean 'releases'?"))
+		})
+
+		It("should suggest keys and named-entry list entries within a small edit distance", func() {
+			example := yml(assets("bosh-yaml", "manifest.yml"))
+			Expect(grabError(example, "/instance_grops")).To(HaveSuffix(", did you mean 'instance_groups'?"))
+			Expect(grabError(example, "/instance_groups/name=wroker")).To(Equal("there is no entry name=wroker in the list, did you mean 'name=worker'?"))
+
+			_, err := ytbx.Grab(example, "/instance_groups/name=wbe")
+			var notFoundErr *ytbx.NamedEntryNotFoundError
+			Expect(errors.As(err, &notFoundErr)).To(BeTrue())
+			Expect(notFoundErr.Suggestions).To(Equal([]string{"web"}))
+		})
+
+		It("should suggest named-entry list entries in Dot-Style paths", func() {
+			_, err := ytbx.ParseDotStylePathString("instance_groups.wroker", singleDoc(`{ instance_groups: [ { name: web }, { name: worker } ] }`))
+			Expect(err).To(MatchError(HaveSuffix("available names are: web, worker, did you mean 'worker'?")))
+		})
+
+		It("should not suggest anything for unrelated keys", func() {
+			example := yml(assets("examples", "types.yml"))
+			Expect(grabError(example, "/yaml/does-not-exist")).ToNot(ContainSubstring("did you mean"))
+		})
+	})
+
+	Context("Typed errors for traversal failures", func() {
+		var example *yamlv3.Node
+
+		BeforeEach(func() {
+			example = yml(assets("examples", "types.yml"))
+		})
+
+		It("should return a type mismatch error with position and alternatives", func() {
+			_, err := ytbx.Grab(example, "/yaml/0")
+
+			var typeMismatchErr *ytbx.TypeMismatchError
+			Expect(errors.As(err, &typeMismatchErr)).To(BeTrue())
+			Expect(typeMismatchErr.Path.String()).To(Equal("/yaml"))
+			Expect(typeMismatchErr.Line).To(Equal(3))
+			Expect(typeMismatchErr.Column).To(Equal(3))
+			Expect(typeMismatchErr.ExpectedType).To(Equal("list"))
+			Expect(typeMismatchErr.ActualType).To(Equal("map"))
+			Expect(typeMismatchErr.Available).To(Equal([]string{"map", "simple-list", "named-entry-list-using-name", "named-entry-list-using-key", "named-entry-list-using-id"}))
+		})
+
+		It("should return an index out of range error", func() {
+			_, err := ytbx.Grab(example, "/yaml/simple-list/5")
+
+			var indexErr *ytbx.IndexOutOfRangeError
+			Expect(errors.As(err, &indexErr)).To(BeTrue())
+			Expect(indexErr.Path.String()).To(Equal("/yaml/simple-list"))
+			Expect(indexErr.Line).To(Equal(22))
+			Expect(indexErr.Index).To(Equal(5))
+			Expect(indexErr.Length).To(Equal(5))
+		})
+
+		It("should return a named entry not found error", func() {
+			_, err := ytbx.Grab(example, "/yaml/named-entry-list-using-key/key=Y")
+
+			var notFoundErr *ytbx.NamedEntryNotFoundError
+			Expect(errors.As(err, &notFoundErr)).To(BeTrue())
+			Expect(notFoundErr.Path.String()).To(Equal("/yaml/named-entry-list-using-key"))
+			Expect(notFoundErr.Line).To(Equal(41))
+			Expect(notFoundErr.AvailableNames).To(Equal([]string{"A", "B", "C", "X", "Z"}))
+		})
+
+		It("should return a key not found error with position", func() {
+			_, err := ytbx.Grab(example, "/yaml/map/nope")
+
+			var keyErr *ytbx.KeyNotFoundInMapError
+			Expect(errors.As(err, &keyErr)).To(BeTrue())
+			Expect(keyErr.Path.String()).To(Equal("/yaml/map"))
+			Expect(keyErr.Line).To(Equal(4))
+		})
+	})
+
+	Context("Grabbing values from multi-document input files", func() {
+		var input ytbx.InputFile
+
+		BeforeEach(func() {
+			var err error
+			input, err = ytbx.LoadFile(assets("kubernetes", "list.yml"))
+			Expect(err).ToNot(HaveOccurred())
+			input = ytbx.NameDocuments(ytbx.ExpandKubernetesLists(input))
+		})
+
+		It("should use the first document if there is no document reference", func() {
+			node, err := ytbx.GrabFromFile(input, "/spec/replicas")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node.Value).To(Equal("2"))
+		})
+
+		It("should grab from the document referenced by index", func() {
+			node, err := ytbx.GrabFromFile(input, "#3/data/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node.Value).To(Equal("bar"))
+
+			node, err = ytbx.GrabFromFile(input, "3:data.foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node.Value).To(Equal("bar"))
+		})
+
+		It("should grab from the document referenced by name", func() {
+			node, err := ytbx.GrabFromFile(input, "#Service\\/default\\/web/spec/ports/0/port")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node.Value).To(Equal("80"))
+		})
+
+		It("should return useful error messages", func() {
+			_, err := ytbx.GrabFromFile(input, "#4/data")
+			Expect(err).To(MatchError("invalid GoPatch style path #4/data, provided document index 4 is not in range: 0..3"))
+
+			_, err = ytbx.GrabFromFile(input, "#nope/data")
+			Expect(err).To(MatchError(ContainSubstring("there is no document named 'nope'")))
+		})
+
+		It("should reject document references when grabbing from a single document", func() {
+			_, err := ytbx.Grab(input.Documents[3], "#3/data/foo")
+			Expect(err).To(MatchError("invalid GoPatch style path #3/data/foo, document reference #3 cannot be resolved in a single document, use an input file instead"))
+
+			node, err := ytbx.Grab(input.Documents[3], "#0/data/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node.Value).To(Equal("bar"))
+		})
+
+		It("should grab keys starting with a hash", func() {
+			Expect(grab(singleDoc(`{"#foo": x}`), "#foo")).To(BeEquivalentTo("x"))
+		})
+	})
+
 	Context("Trying to get values by path in an empty file", func() {
 		It("should return a not found key error", func() {
 			emptyFile := yml(assets("examples", "empty.yml"))
 			Expect(grabError(emptyFile, "/does-not-exist")).To(
-				BeEquivalentTo("failed to traverse tree, expected map but found type string at /"),
+				BeEquivalentTo("failed to traverse tree, expected map but found type null at /"),
 			)
 		})
 	})
@@ -0,0 +1,196 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// Tags of the YAML core schema that are used to decide on scalar types
+const (
+	tagString = "!!str"
+	tagInt    = "!!int"
+	tagFloat  = "!!float"
+	tagBool   = "!!bool"
+	tagNull   = "!!null"
+)
+
+// GrabString returns the value referenced by the path as a string. Any scalar
+// value except null is accepted, since numbers or booleans are often meant to
+// be strings, for example a version like `1.0`.
+func GrabString(node *yamlv3.Node, pathString string) (string, error) {
+	value, path, err := grabWithPath(node, pathString)
+	if err != nil {
+		return "", err
+	}
+
+	if value.Kind != yamlv3.ScalarNode || value.ShortTag() == tagNull {
+		return "", newValueTypeMismatchError(path, "string", value, nil)
+	}
+
+	return value.Value, nil
+}
+
+// GrabInt returns the value referenced by the path as an integer
+func GrabInt(node *yamlv3.Node, pathString string) (int, error) {
+	var result int
+	return result, grabTypedScalar(node, pathString, "int", &result, tagInt)
+}
+
+// GrabFloat returns the value referenced by the path as a floating point
+// number, integer values are accepted as well
+func GrabFloat(node *yamlv3.Node, pathString string) (float64, error) {
+	var result float64
+	return result, grabTypedScalar(node, pathString, "float", &result, tagFloat, tagInt)
+}
+
+// GrabBool returns the value referenced by the path as a boolean
+func GrabBool(node *yamlv3.Node, pathString string) (bool, error) {
+	var result bool
+	return result, grabTypedScalar(node, pathString, "bool", &result, tagBool)
+}
+
+// GrabDuration returns the value referenced by the path as a duration, which
+// needs to be a string in the format of `time.ParseDuration`, e.g. `1h30m`
+func GrabDuration(node *yamlv3.Node, pathString string) (time.Duration, error) {
+	value, path, err := grabWithPath(node, pathString)
+	if err != nil {
+		return 0, err
+	}
+
+	if value.Kind != yamlv3.ScalarNode || value.ShortTag() != tagString {
+		return 0, newValueTypeMismatchError(path, "duration", value, nil)
+	}
+
+	duration, err := time.ParseDuration(value.Value)
+	if err != nil {
+		return 0, newValueTypeMismatchError(path, "duration", value, err)
+	}
+
+	return duration, nil
+}
+
+// GrabStringSlice returns the list referenced by the path as a slice of
+// strings, where each list entry has to be a scalar value other than null
+func GrabStringSlice(node *yamlv3.Node, pathString string) ([]string, error) {
+	value, path, err := grabWithPath(node, pathString)
+	if err != nil {
+		return nil, err
+	}
+
+	if value.Kind != yamlv3.SequenceNode {
+		return nil, newValueTypeMismatchError(path, "list of strings", value, nil)
+	}
+
+	result := make([]string, len(value.Content))
+	for idx, entry := range value.Content {
+		entry = resolveAlias(entry)
+		if entry.Kind != yamlv3.ScalarNode || entry.ShortTag() == tagNull {
+			return nil, newValueTypeMismatchError(NewPathWithIndexedListElement(path, idx), "string", entry, nil)
+		}
+
+		result[idx] = entry.Value
+	}
+
+	return result, nil
+}
+
+// GrabInto decodes the value referenced by the path into the provided value,
+// which works like `yaml.Unmarshal` for example to decode into a struct
+func GrabInto(node *yamlv3.Node, pathString string, v interface{}) error {
+	value, path, err := grabWithPath(node, pathString)
+	if err != nil {
+		return err
+	}
+
+	if err := value.Decode(v); err != nil {
+		return newValueTypeMismatchError(path, fmt.Sprintf("%T", v), value, err)
+	}
+
+	return nil
+}
+
+// GrabOr uses the provided typed grab function, for example `GrabInt`, to get
+// the value referenced by the path, but returns the fallback value in case the
+// path does not exist in the YAML tree. Errors other than a missing path, like
+// invalid path strings or type mismatches, are still returned.
+func GrabOr[T any](grab func(*yamlv3.Node, string) (T, error), node *yamlv3.Node, pathString string, fallback T) (T, error) {
+	value, err := grab(node, pathString)
+	if isMissingPathError(err) {
+		return fallback, nil
+	}
+
+	return value, err
+}
+
+// grabWithPath returns the value referenced by the path with aliases being
+// resolved, together with the parsed path for error reporting
+func grabWithPath(node *yamlv3.Node, pathString string) (*yamlv3.Node, Path, error) {
+	path, err := ParsePathString(pathString, node)
+	if err != nil {
+		return nil, Path{}, err
+	}
+
+	if err := rejectDocumentReference(pathString, path); err != nil {
+		return nil, Path{}, err
+	}
+
+	value, err := grabByPath(documentRoot(node), path)
+	if err != nil {
+		return nil, Path{}, err
+	}
+
+	return resolveAlias(value), path, nil
+}
+
+// isMissingPathError returns whether the error reports a key, named entry, or
+// list index that does not exist in the YAML tree
+func isMissingPathError(err error) bool {
+	var keyNotFoundErr *KeyNotFoundInMapError
+	var namedEntryNotFoundErr *NamedEntryNotFoundError
+	var indexOutOfRangeErr *IndexOutOfRangeError
+
+	return errors.As(err, &keyNotFoundErr) ||
+		errors.As(err, &namedEntryNotFoundErr) ||
+		errors.As(err, &indexOutOfRangeErr)
+}
+
+func grabTypedScalar(node *yamlv3.Node, pathString string, expected string, v interface{}, tags ...string) error {
+	value, path, err := grabWithPath(node, pathString)
+	if err != nil {
+		return err
+	}
+
+	if value.Kind != yamlv3.ScalarNode || !slices.Contains(tags, value.ShortTag()) {
+		return newValueTypeMismatchError(path, expected, value, nil)
+	}
+
+	if err := value.Decode(v); err != nil {
+		return newValueTypeMismatchError(path, expected, value, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+var _ = Describe("Grabbing typed values", func() {
+	var example *yamlv3.Node
+
+	BeforeEach(func() {
+		example = yml(assets("examples", "types.yml"))
+	})
+
+	Context("Grabbing scalar values", func() {
+		It("should return values of the expected type", func() {
+			Expect(GrabString(example, "/yaml/map/before")).To(Equal("after"))
+			Expect(GrabString(example, "/yaml/map/intA")).To(Equal("42"))
+			Expect(GrabInt(example, "/yaml/map/intA")).To(Equal(42))
+			Expect(GrabFloat(example, "/yaml/map/floatA")).To(Equal(3.1415))
+			Expect(GrabFloat(example, "/yaml/map/intB")).To(Equal(10.0))
+			Expect(GrabBool(example, "/yaml/map/boolA")).To(BeTrue())
+			Expect(GrabStringSlice(example, "/yaml/map/listA")).To(Equal([]string{"A", "A", "A"}))
+			Expect(GrabDuration(yml("{ timeout: 1h30m }"), "/timeout")).To(Equal(90 * time.Minute))
+		})
+
+		It("should return typed errors on type mismatches", func() {
+			_, err := GrabInt(example, "/yaml/map/before")
+			Expect(err).To(MatchError("value at /yaml/map/before cannot be used as int, found type string"))
+
+			var typeErr *TypeMismatchError
+			Expect(errors.As(err, &typeErr)).To(BeTrue())
+			Expect(typeErr.Path.String()).To(Equal("/yaml/map/before"))
+			Expect(typeErr.ExpectedType).To(Equal("int"))
+
+			_, err = GrabBool(example, "/yaml/map/mapA")
+			Expect(err).To(MatchError("value at /yaml/map/mapA cannot be used as bool, found type map"))
+
+			_, err = GrabStringSlice(example, "/yaml/named-entry-list-using-name")
+			Expect(err).To(MatchError("value at /yaml/named-entry-list-using-name/0 cannot be used as string, found type map"))
+
+			_, err = GrabDuration(example, "/yaml/map/before")
+			Expect(err).To(MatchError(ContainSubstring("value at /yaml/map/before cannot be used as duration (found type string)")))
+		})
+
+		It("should return errors for missing paths", func() {
+			_, err := GrabString(example, "/yaml/map/nope")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Decoding values", func() {
+		It("should decode into the provided value", func() {
+			var entry struct {
+				Name string `yaml:"name"`
+				Foo  string `yaml:"foo"`
+			}
+
+			Expect(GrabInto(example, "/yaml/named-entry-list-using-name/name=B", &entry)).To(Succeed())
+			Expect(entry.Name).To(Equal("B"))
+			Expect(entry.Foo).To(Equal("bar"))
+		})
+
+		It("should fail with a typed error if the value cannot be decoded", func() {
+			var numbers []int
+			err := GrabInto(example, "/yaml/map/listA", &numbers)
+
+			var typeErr *TypeMismatchError
+			Expect(errors.As(err, &typeErr)).To(BeTrue())
+			Expect(typeErr.ExpectedType).To(Equal("*[]int"))
+		})
+	})
+
+	Context("Grabbing values behind aliases and in other documents", func() {
+		It("should resolve aliases to the value they refer to", func() {
+			doc := singleDoc("a: &x 5\nb: *x\nd: [ *x ]\n")
+			Expect(GrabInt(doc, "/b")).To(Equal(5))
+			Expect(GrabString(doc, "/b")).To(Equal("5"))
+			Expect(GrabStringSlice(doc, "/d")).To(Equal([]string{"5"}))
+		})
+
+		It("should fail for paths referring to another document", func() {
+			_, err := GrabString(singleDoc("a: foo"), "#1/a")
+			var invalidPathErr *InvalidPathString
+			Expect(errors.As(err, &invalidPathErr)).To(BeTrue())
+		})
+	})
+
+	Context("Grabbing values with defaults", func() {
+		It("should return the fallback value for missing paths", func() {
+			Expect(GrabOr(GrabInt, example, "/yaml/map/nope", 7)).To(Equal(7))
+			Expect(GrabOr(GrabInt, example, "/yaml/nope/deeper", 7)).To(Equal(7))
+			Expect(GrabOr(GrabInt, example, "/yaml/map/intA", 7)).To(Equal(42))
+		})
+
+		It("should still fail on type mismatches", func() {
+			_, err := GrabOr(GrabInt, example, "/yaml/map/before", 7)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should still fail on invalid paths", func() {
+			_, err := GrabOr(GrabString, example, "/yaml/map/a=b=c", "fallback")
+			var invalidPathErr *InvalidPathString
+			Expect(errors.As(err, &invalidPathErr)).To(BeTrue())
+
+			_, err = GrabOr(GrabString, example, "/yaml/map/before/b/c", "fallback")
+			var typeMismatchErr *TypeMismatchError
+			Expect(errors.As(err, &typeMismatchErr)).To(BeTrue())
+		})
+	})
+})
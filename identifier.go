@@ -0,0 +1,202 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"strings"
+	"sync"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// DefaultIdentifierResolver is the global registry of named-entry list
+// identifiers, which is used when traversing YAML structures, for example in
+// `ListPaths`, or when parsing Dot-Style paths.
+var DefaultIdentifierResolver = NewIdentifierResolver("name", "key", "id")
+
+// IdentifierResolver decides which key is used to identify the entries of a
+// named-entry list. It has a list of identifier keys that are checked in order
+// for all lists, and optional overrides for lists at specific paths. Entries
+// can also be identified by a composite identifier, which is a combination of
+// multiple keys, for example `containerPort` and `protocol`.
+type IdentifierResolver struct {
+	mutex       sync.RWMutex
+	identifiers [][]string
+	overrides   []identifierOverride
+}
+
+type identifierOverride struct {
+	pattern []string
+	keys    []string
+}
+
+// NewIdentifierResolver creates a new resolver with the provided identifier
+// keys, which are checked in the given order.
+func NewIdentifierResolver(identifiers ...string) *IdentifierResolver {
+	r := &IdentifierResolver{}
+	r.AddIdentifiers(identifiers...)
+	return r
+}
+
+// AddIdentifiers registers additional identifier keys, which are checked after
+// the already known identifier keys.
+func (r *IdentifierResolver) AddIdentifiers(identifiers ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, identifier := range identifiers {
+		r.identifiers = append(r.identifiers, []string{identifier})
+	}
+}
+
+// AddCompositeIdentifier registers an additional identifier that consists of
+// multiple keys, for example `name` and `type`, which is checked after the
+// already known identifiers.
+func (r *IdentifierResolver) AddCompositeIdentifier(keys ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.identifiers = append(r.identifiers, keys)
+}
+
+// AddPathIdentifier registers an identifier key for lists at the paths that
+// match the provided GoPatch style pattern, where an asterisk matches exactly
+// one path element and a double asterisk any number of path elements, for
+// example `/spec/containers/*/ports` or `**/containers/*/ports` with identifier
+// `containerPort`. More than one key registers a composite identifier. Later
+// registrations take precedence over earlier ones.
+func (r *IdentifierResolver) AddPathIdentifier(pattern string, keys ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.overrides = append(r.overrides, identifierOverride{
+		pattern: patternSections(pattern),
+		keys:    keys,
+	})
+}
+
+// Identifiers returns the list of identifiers that are checked for all lists
+// regardless of their path. Each identifier is a list of keys, which has more
+// than one entry in case of a composite identifier.
+func (r *IdentifierResolver) Identifiers() [][]string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make([][]string, len(r.identifiers))
+	for i, keys := range r.identifiers {
+		result[i] = append([]string{}, keys...)
+	}
+
+	return result
+}
+
+// Identifier returns the identifier keys used in the provided list located at
+// the given path, or nil if there is none. An identifier registered for the
+// path is used if all list entries have its keys, otherwise the general
+// identifiers are checked. Composite identifiers have more than one key.
+func (r *IdentifierResolver) Identifier(path Path, sequenceNode *yamlv3.Node) []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	sections := path.sections()
+	for i := len(r.overrides) - 1; i >= 0; i-- {
+		override := r.overrides[i]
+		if matchesPattern(override.pattern, sections) {
+			if keys := identifierFromCandidates(sequenceNode, [][]string{override.keys}); keys != nil {
+				return keys
+			}
+		}
+	}
+
+	return identifierFromCandidates(sequenceNode, r.identifiers)
+}
+
+// patternSections splits a GoPatch style path pattern into its sections
+func patternSections(pattern string) []string {
+	sections := []string{}
+	if trimmed := strings.Trim(pattern, "/"); trimmed != "" {
+		sections = strings.Split(trimmed, "/")
+	}
+
+	return sections
+}
+
+// matchesPattern returns whether the path sections match the pattern, where
+// `*` matches exactly one section and `**` matches any number of sections
+func matchesPattern(pattern []string, sections []string) bool {
+	switch {
+	case len(pattern) == 0:
+		return len(sections) == 0
+
+	case pattern[0] == "**":
+		for i := 0; i <= len(sections); i++ {
+			if matchesPattern(pattern[1:], sections[i:]) {
+				return true
+			}
+		}
+
+		return false
+
+	case len(sections) == 0:
+		return false
+
+	case pattern[0] != "*" && pattern[0] != sections[0]:
+		return false
+
+	default:
+		return matchesPattern(pattern[1:], sections[1:])
+	}
+}
+
+// identifierFromCandidates returns the first of the candidate identifiers whose
+// keys are used in every entry of the list, or nil if there is none
+func identifierFromCandidates(sequenceNode *yamlv3.Node, candidates [][]string) []string {
+	counters := map[string]int{}
+
+	for _, mappingNode := range sequenceNode.Content {
+		if mappingNode.Kind != yamlv3.MappingNode {
+			continue
+		}
+
+		for i := 0; i < len(mappingNode.Content); i += 2 {
+			counters[mappingNode.Content[i].Value]++
+		}
+	}
+
+	listLength := len(sequenceNode.Content)
+	for _, keys := range candidates {
+		if len(keys) > 0 && isUsedInAllEntries(keys, counters, listLength) {
+			return keys
+		}
+	}
+
+	return nil
+}
+
+func isUsedInAllEntries(keys []string, counters map[string]int, listLength int) bool {
+	for _, key := range keys {
+		if count, ok := counters[key]; !ok || count != listLength {
+			return false
+		}
+	}
+
+	return true
+}
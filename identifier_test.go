@@ -0,0 +1,132 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+)
+
+var _ = Describe("Named-entry list identifiers", func() {
+	var original *IdentifierResolver
+
+	BeforeEach(func() {
+		original = DefaultIdentifierResolver
+		DefaultIdentifierResolver = NewIdentifierResolver("name", "key", "id")
+	})
+
+	AfterEach(func() {
+		DefaultIdentifierResolver = original
+	})
+
+	listPaths := func() []string {
+		paths, err := ListPaths(assets("kubernetes", "deployment.yml"))
+		Expect(err).ToNot(HaveOccurred())
+
+		result := []string{}
+		for _, path := range paths {
+			result = append(result, path.String())
+		}
+
+		return result
+	}
+
+	Context("Using the default identifiers", func() {
+		It("should use indexes for lists without known identifiers", func() {
+			Expect(listPaths()).To(ContainElement("/spec/template/spec/containers/name=web/ports/0/containerPort"))
+			Expect(listPaths()).To(ContainElement("/spec/template/spec/containers/name=web/volumeMounts/0/mountPath"))
+		})
+
+		It("should treat numeric Dot-Style sections as list indexes", func() {
+			input := singleDoc(`{list: [{name: a}, {name: '0'}]}`)
+			Expect(grab(input, "list.0.name")).To(BeEquivalentTo("a"))
+			Expect(grab(input, "list.name=0.name")).To(BeEquivalentTo("0"))
+		})
+	})
+
+	Context("Using additional identifiers", func() {
+		It("should use registered identifiers for all lists", func() {
+			DefaultIdentifierResolver.AddIdentifiers("mountPath")
+			Expect(listPaths()).To(ContainElement("/spec/template/spec/containers/name=web/volumeMounts/mountPath=/etc/nginx/readOnly"))
+			Expect(DefaultIdentifierResolver.Identifiers()).To(Equal([][]string{{"name"}, {"key"}, {"id"}, {"mountPath"}}))
+		})
+
+		It("should use identifiers registered for matching paths", func() {
+			DefaultIdentifierResolver.AddPathIdentifier("/spec/template/spec/containers/*/ports", "containerPort")
+			Expect(listPaths()).To(ContainElement("/spec/template/spec/containers/name=web/ports/containerPort=443/protocol"))
+			Expect(listPaths()).To(ContainElement("/spec/template/spec/containers/name=web/volumeMounts/0/mountPath"))
+		})
+
+		It("should support patterns matching any number of path elements", func() {
+			DefaultIdentifierResolver.AddPathIdentifier("**/containers/*/ports", "containerPort")
+			Expect(listPaths()).To(ContainElement("/spec/template/spec/containers/name=web/ports/containerPort=443/protocol"))
+		})
+
+		It("should support composite identifiers", func() {
+			DefaultIdentifierResolver.AddPathIdentifier("/spec/template/spec/containers/*/ports", "containerPort", "protocol")
+			Expect(listPaths()).To(ContainElement("/spec/template/spec/containers/name=web/volumeMounts/0/mountPath"))
+			Expect(listPaths()).To(ContainElement("/spec/template/spec/containers/name=web/ports/containerPort=443,protocol=TCP/name"))
+
+			input, err := LoadFile(assets("kubernetes", "deployment.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			ports, err := Grab(input.Documents[0], "/spec/template/spec/containers/name=web/ports")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(DefaultIdentifierResolver.Identifier(Path{}, ports)).To(BeNil())
+
+			path, err := ParseGoPatchStylePathString("/spec/template/spec/containers/name=web/ports")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(DefaultIdentifierResolver.Identifier(path, ports)).To(Equal([]string{"containerPort", "protocol"}))
+
+			port, err := Grab(input.Documents[0], "/spec/template/spec/containers/name=web/ports/containerPort=443,protocol=TCP")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(port).To(BeAsNode(yml("{ containerPort: 443, protocol: TCP, name: https }")))
+
+			_, err = Grab(input.Documents[0], "/spec/template/spec/containers/name=web/ports/containerPort=443,protocol=UDP")
+			Expect(err).To(MatchError("there is no entry containerPort=443,protocol=UDP in the list, did you mean 'containerPort=443,protocol=TCP'?"))
+		})
+
+		It("should register composite identifiers for all lists", func() {
+			DefaultIdentifierResolver.AddCompositeIdentifier("containerPort", "protocol")
+			Expect(listPaths()).To(ContainElement("/spec/template/spec/containers/name=web/ports/containerPort=443,protocol=TCP/name"))
+			Expect(DefaultIdentifierResolver.Identifiers()).To(ContainElement([]string{"containerPort", "protocol"}))
+		})
+
+		It("should not split identifier keys that contain commas", func() {
+			input := singleDoc(`{list: [{"a,b": x, c: 1}, {"a,b": y, c: 2}]}`)
+			DefaultIdentifierResolver.AddIdentifiers("a,b")
+			Expect(grab(input, "/list/a,b=y/c")).To(BeEquivalentTo(2))
+		})
+
+		It("should honor identifiers when parsing Dot-Style paths", func() {
+			DefaultIdentifierResolver.AddPathIdentifier("/spec/template/spec/containers/*/ports", "containerPort")
+
+			input, err := LoadFile(assets("kubernetes", "deployment.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			path, err := ParseDotStylePathString("spec.template.spec.containers.web.ports.containerPort=443.protocol", input.Documents[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path.String()).To(Equal("/spec/template/spec/containers/name=web/ports/containerPort=443/protocol"))
+		})
+	})
+})
@@ -44,6 +44,11 @@ import (
 // of the JSON specification.
 var PreserveKeyOrderInJSON = false
 
+// MaxDecompressedSize is the maximum number of bytes that are read from a
+// compressed input or archive member, which protects against decompression
+// bombs. Input exceeding the limit results in an error.
+var MaxDecompressedSize int64 = 512 << 20
+
 // DecoderProxy can either be used with the standard JSON Decoder, or the
 // specialised JSON library fork that supports preserving key order
 type DecoderProxy struct {
@@ -156,7 +161,9 @@ func LoadFiles(locationA string, locationB string) (InputFile, InputFile, error)
 }
 
 // LoadFile processes the provided input location to load it as one of the
-// supported document formats, or plain text if nothing else works.
+// supported document formats, or plain text if nothing else works. Gzip
+// compressed input is decompressed transparently and tar or zip archives are
+// processed like directories (see `LoadDirectory`).
 func LoadFile(location string) (InputFile, error) {
 	if info, err := os.Stat(location); err == nil && info.IsDir() {
 		return LoadDirectory(location)
@@ -172,6 +179,14 @@ func LoadFile(location string) (InputFile, error) {
 		return InputFile{}, fmt.Errorf("unable to load data from %s: %w", HumanReadableLocation(location), err)
 	}
 
+	if data, err = decompress(data); err != nil {
+		return InputFile{}, fmt.Errorf("unable to decompress data from %s: %w", HumanReadableLocation(location), err)
+	}
+
+	if isArchive(data) {
+		return loadArchive(location, data)
+	}
+
 	if documents, err = LoadDocuments(data); err != nil {
 		return InputFile{}, fmt.Errorf("unable to parse data from %s: %w", HumanReadableLocation(location), err)
 	}
@@ -187,7 +202,7 @@ func LoadFile(location string) (InputFile, error) {
 func LoadDirectory(location string) (InputFile, error) {
 	files, err := os.ReadDir(location)
 	if err != nil {
-		return InputFile{}, fmt.Errorf("failed to read files in directory %s: %w", location, err)
+		return InputFile{}, fmt.Errorf("failed to read files in directory %s: %w", HumanReadableLocation(location), err)
 	}
 
 	sort.Slice(files, func(i, j int) bool {
@@ -204,18 +219,62 @@ func LoadDirectory(location string) (InputFile, error) {
 			return InputFile{}, err
 		}
 
-		docs, err := LoadDocuments(bytes)
+		if err := result.appendDocuments(file.Name(), bytes); err != nil {
+			return InputFile{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// loadArchive processes all files in the provided tar or zip archive data as
+// documents using the same semantics as `LoadDirectory`
+func loadArchive(location string, data []byte) (InputFile, error) {
+	entries, err := readArchive(data)
+	if err != nil {
+		return InputFile{}, fmt.Errorf("failed to read archive %s: %w", HumanReadableLocation(location), err)
+	}
+
+	var result = InputFile{
+		Location: location,
+	}
+
+	for _, entry := range entries {
+		bytes, err := decompress(entry.data)
 		if err != nil {
-			return InputFile{}, fmt.Errorf("failed to read %s: %w", filepath.Join(location, file.Name()), err)
+			return InputFile{}, fmt.Errorf("failed to decompress %s in %s: %w", entry.name, HumanReadableLocation(location), err)
 		}
 
-		result.Documents = append(result.Documents, docs...)
-		result.Names = append(result.Names, file.Name())
+		if err := result.appendDocuments(entry.name, bytes); err != nil {
+			return InputFile{}, err
+		}
 	}
 
 	return result, nil
 }
 
+// appendDocuments parses the data of the named directory file or archive
+// member and adds its documents to the input file. Each document gets its own
+// name to keep names and documents aligned: the plain name if there is only
+// one document, otherwise the name with the document index (`name#idx`).
+func (inputFile *InputFile) appendDocuments(name string, data []byte) error {
+	docs, err := LoadDocuments(data)
+	if err != nil {
+		return fmt.Errorf("failed to read %s in %s: %w", name, HumanReadableLocation(inputFile.Location), err)
+	}
+
+	for idx, doc := range docs {
+		inputFile.Documents = append(inputFile.Documents, doc)
+		if len(docs) == 1 {
+			inputFile.Names = append(inputFile.Names, name)
+		} else {
+			inputFile.Names = append(inputFile.Names, fmt.Sprintf("%s#%d", name, idx))
+		}
+	}
+
+	return nil
+}
+
 // LoadDocuments reads the provided input data slice as a YAML, JSON, or TOML
 // file with potential multiple documents. It only acts as a dispatcher and
 // depending on the input will either use `LoadTOMLDocuments`,
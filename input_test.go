@@ -21,10 +21,15 @@
 package ytbx_test
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -49,6 +54,100 @@ var _ = Describe("Input test cases", func() {
 		})
 	})
 
+	Context("Input data from compressed and archived sources", func() {
+		var members = map[string]string{
+			"b.yml": "---\nname: b\n",
+			"a.yml": "---\nname: a\n",
+		}
+
+		gzipped := func(data []byte) []byte {
+			var buf bytes.Buffer
+			w := gzip.NewWriter(&buf)
+			_, err := w.Write(data)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(w.Close()).To(Succeed())
+			return buf.Bytes()
+		}
+
+		writeFile := func(name string, data []byte) string {
+			location := filepath.Join(GinkgoT().TempDir(), name)
+			Expect(os.WriteFile(location, data, 0644)).To(Succeed())
+			return location
+		}
+
+		It("should load a gzip compressed YAML file", func() {
+			inputfile, err := LoadFile(writeFile("types.yml.gz", gzipped([]byte("---\nfoo: bar\n"))))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inputfile.Documents).To(HaveLen(1))
+			Expect(inputfile.Documents[0].Content[0]).To(BeAsNode(yml("foo: bar")))
+		})
+
+		It("should load a compressed tar archive like a directory", func() {
+			var buf bytes.Buffer
+			w := tar.NewWriter(&buf)
+			Expect(w.WriteHeader(&tar.Header{Name: "chart/", Typeflag: tar.TypeDir, Mode: 0755})).To(Succeed())
+			for name, content := range members {
+				Expect(w.WriteHeader(&tar.Header{Name: "chart/" + name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))})).To(Succeed())
+				_, err := w.Write([]byte(content))
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(w.Close()).To(Succeed())
+
+			inputfile, err := LoadFile(writeFile("chart.tgz", gzipped(buf.Bytes())))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inputfile.Names).To(Equal([]string{"chart/a.yml", "chart/b.yml"}))
+			Expect(inputfile.Documents).To(HaveLen(2))
+			Expect(inputfile.Documents[0].Content[0]).To(BeAsNode(yml("name: a")))
+			Expect(inputfile.Documents[1].Content[0]).To(BeAsNode(yml("name: b")))
+		})
+
+		It("should load a zip archive like a directory", func() {
+			var buf bytes.Buffer
+			w := zip.NewWriter(&buf)
+			for name, content := range members {
+				f, err := w.Create(name)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = f.Write([]byte(content))
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(w.Close()).To(Succeed())
+
+			inputfile, err := LoadFile(writeFile("release.zip", buf.Bytes()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inputfile.Names).To(Equal([]string{"a.yml", "b.yml"}))
+			Expect(inputfile.Documents).To(HaveLen(2))
+			Expect(inputfile.Documents[0].Content[0]).To(BeAsNode(yml("name: a")))
+			Expect(inputfile.Documents[1].Content[0]).To(BeAsNode(yml("name: b")))
+		})
+
+		It("should name each document of an archive member with multiple documents", func() {
+			var buf bytes.Buffer
+			w := zip.NewWriter(&buf)
+			for name, content := range map[string]string{"a.yml": "---\nname: a\n", "b.yml": "---\nname: b0\n---\nname: b1\n"} {
+				f, err := w.Create(name)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = f.Write([]byte(content))
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(w.Close()).To(Succeed())
+
+			inputfile, err := LoadFile(writeFile("release.zip", buf.Bytes()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inputfile.Names).To(Equal([]string{"a.yml", "b.yml#0", "b.yml#1"}))
+			Expect(inputfile.Documents).To(HaveLen(3))
+			Expect(inputfile.Documents[2].Content[0]).To(BeAsNode(yml("name: b1")))
+		})
+
+		It("should fail to load compressed data exceeding the decompression limit", func() {
+			defer func(limit int64) { MaxDecompressedSize = limit }(MaxDecompressedSize)
+			MaxDecompressedSize = 1024
+
+			_, err := LoadFile(writeFile("large.yml.gz", gzipped(bytes.Repeat([]byte("# padding\n"), 1024))))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("exceeds the limit of 1024 bytes"))
+		})
+	})
+
 	Context("Input data from remote locations", func() {
 		var server *httptest.Server
 
@@ -0,0 +1,142 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"fmt"
+	"strings"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// ExpandKubernetesLists returns a copy of the input file where all Kubernetes
+// List documents (for example the output of `kubectl get -o yaml`) are replaced
+// with one document per entry in the `items` list. Each of these new documents
+// is named using kind, namespace, and name of the resource, for example
+// `Deployment/default/web`.
+func ExpandKubernetesLists(inputFile InputFile) InputFile {
+	var (
+		documents []*yamlv3.Node
+		names     []string
+	)
+
+	for idx, document := range inputFile.Documents {
+		items, ok := kubernetesListItems(document)
+		if !ok {
+			documents = append(documents, document)
+			names = append(names, documentName(inputFile, idx, document, len(documents)-1))
+			continue
+		}
+
+		for _, item := range items {
+			documents = append(documents, &yamlv3.Node{
+				Kind:    yamlv3.DocumentNode,
+				Content: []*yamlv3.Node{item},
+			})
+
+			name, ok := kubernetesResourceName(item)
+			if !ok {
+				name = fmt.Sprintf("document #%d", len(documents))
+			}
+
+			names = append(names, name)
+		}
+	}
+
+	return InputFile{
+		Location:  inputFile.Location,
+		Note:      inputFile.Note,
+		Documents: documents,
+		Names:     names,
+	}
+}
+
+// documentName returns the name of an unchanged document using the existing
+// name if the names match the documents one-to-one, or a generated name
+func documentName(inputFile InputFile, idx int, document *yamlv3.Node, newIdx int) string {
+	if len(inputFile.Names) == len(inputFile.Documents) {
+		return inputFile.Names[idx]
+	}
+
+	if name, ok := kubernetesResourceName(document); ok {
+		return name
+	}
+
+	// Note: human style counting that starts with 1
+	return fmt.Sprintf("document #%d", newIdx+1)
+}
+
+// kubernetesListItems returns the entries of a Kubernetes List document, which
+// is a document of kind `List` (or any other kind ending with `List`) that has
+// an `items` list
+func kubernetesListItems(node *yamlv3.Node) ([]*yamlv3.Node, bool) {
+	node = documentRoot(node)
+
+	if node.Kind != yamlv3.MappingNode {
+		return nil, false
+	}
+
+	if _, err := getValueByKey(node, "apiVersion"); err != nil {
+		return nil, false
+	}
+
+	kind, err := getValueByKey(node, "kind")
+	if err != nil || !strings.HasSuffix(kind.Value, "List") {
+		return nil, false
+	}
+
+	items, err := getValueByKey(node, "items")
+	if err != nil || items.Kind != yamlv3.SequenceNode {
+		return nil, false
+	}
+
+	return items.Content, true
+}
+
+// kubernetesResourceName returns a name in the format kind/namespace/name for
+// the provided Kubernetes resource, or kind/name for cluster scoped resources
+func kubernetesResourceName(node *yamlv3.Node) (string, bool) {
+	node = documentRoot(node)
+	if node.Kind != yamlv3.MappingNode {
+		return "", false
+	}
+
+	kind, err := getValueByKey(node, "kind")
+	if err != nil {
+		return "", false
+	}
+
+	metadata, err := getValueByKey(node, "metadata")
+	if err != nil || metadata.Kind != yamlv3.MappingNode {
+		return "", false
+	}
+
+	name, err := getValueByKey(metadata, "name")
+	if err != nil {
+		return "", false
+	}
+
+	if namespace, err := getValueByKey(metadata, "namespace"); err == nil {
+		return fmt.Sprintf("%s/%s/%s", kind.Value, namespace.Value, name.Value), true
+	}
+
+	return fmt.Sprintf("%s/%s", kind.Value, name.Value), true
+}
@@ -0,0 +1,60 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+)
+
+var _ = Describe("Kubernetes specific input processing", func() {
+	Context("Expanding Kubernetes List documents", func() {
+		It("should split the list items into separate documents", func() {
+			input, err := LoadFile(assets("kubernetes", "list.yml"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(input.Documents).To(HaveLen(2))
+
+			expanded := ExpandKubernetesLists(input)
+			Expect(expanded.Location).To(Equal(input.Location))
+			Expect(expanded.Documents).To(HaveLen(4))
+			Expect(expanded.Names).To(Equal([]string{
+				"Deployment/default/web",
+				"Service/default/web",
+				"Namespace/default",
+				"ConfigMap/default/settings",
+			}))
+
+			Expect(grab(expanded.Documents[0], "/spec/replicas")).To(BeEquivalentTo(2))
+			Expect(grab(expanded.Documents[3], "/data/foo")).To(BeEquivalentTo("bar"))
+		})
+
+		It("should keep documents that are no lists untouched", func() {
+			input, err := LoadFile(assets("examples", "types.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			expanded := ExpandKubernetesLists(input)
+			Expect(expanded.Documents).To(Equal(input.Documents))
+			Expect(expanded.Names).To(Equal([]string{"document #1"}))
+		})
+	})
+})
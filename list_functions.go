@@ -22,48 +22,32 @@ package ytbx
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	yamlv3 "go.yaml.in/yaml/v3"
 )
 
 // GetIdentifierFromNamedList returns the identifier key used in the provided
 // list, or an empty string if there is none.
-// The identifier key is either 'name', 'key', or 'id'.
+// The identifier key is one of the keys registered in the
+// `DefaultIdentifierResolver`, which are 'name', 'key', or 'id' by default.
+// Composite identifiers are returned as a comma separated list of keys, for
+// example `containerPort,protocol`.
 func GetIdentifierFromNamedList(sequenceNode *yamlv3.Node) string {
-	counters := map[string]int{}
-
-	for _, mappingNode := range sequenceNode.Content {
-		for i := 0; i < len(mappingNode.Content); i += 2 {
-			k := mappingNode.Content[i]
-
-			if _, ok := counters[k.Value]; !ok {
-				counters[k.Value] = 0
-			}
-
-			counters[k.Value]++
-		}
-	}
-
-	listLength := len(sequenceNode.Content)
-	for _, identifier := range []string{"name", "key", "id"} {
-		if count, ok := counters[identifier]; ok && count == listLength {
-			return identifier
-		}
-	}
-
-	return ""
+	return strings.Join(identifierFromCandidates(sequenceNode, DefaultIdentifierResolver.Identifiers()), ",")
 }
 
 // getEntryFromNamedList returns the entry that is identified by the identifier
-// key and a name, for example: `name: one` where name is the identifier key and
+// keys and names, for example: `name: one` where name is the identifier key and
 // one the name. Function will return nil with bool false if there is no entry.
-func getEntryFromNamedList(sequenceNode *yamlv3.Node, identifier string, name string) (*yamlv3.Node, bool) {
-	node, err := getEntryByIdentifierAndName(sequenceNode, identifier, name)
+func getEntryFromNamedList(sequenceNode *yamlv3.Node, keys []string, names []string) (*yamlv3.Node, bool) {
+	node, err := getEntryByIdentifierAndName(sequenceNode, keys, names)
 	return node, err == nil
 }
 
-func getEntryByIdentifierAndName(sequenceNode *yamlv3.Node, identifier string, name string) (*yamlv3.Node, error) {
-	idx, err := getIndexByIdentifierAndName(sequenceNode, identifier, name)
+func getEntryByIdentifierAndName(sequenceNode *yamlv3.Node, keys []string, names []string) (*yamlv3.Node, error) {
+	idx, err := getIndexByIdentifierAndName(sequenceNode, keys, names)
 	if err != nil {
 		return nil, err
 	}
@@ -71,24 +55,42 @@ func getEntryByIdentifierAndName(sequenceNode *yamlv3.Node, identifier string, n
 	return sequenceNode.Content[idx], nil
 }
 
-func getIndexByIdentifierAndName(sequenceNode *yamlv3.Node, identifier string, name string) (int, error) {
+func getIndexByIdentifierAndName(sequenceNode *yamlv3.Node, keys []string, names []string) (int, error) {
 	for idx, mappingNode := range sequenceNode.Content {
-		for i := 0; i < len(mappingNode.Content); i += 2 {
-			k, v := mappingNode.Content[i], mappingNode.Content[i+1]
-			if k.Value == identifier && v.Value == name {
-				return idx, nil
-			}
+		if mappingNode.Kind != yamlv3.MappingNode {
+			continue
+		}
+
+		if entryNames, err := getNameByIdentifier(mappingNode, keys); err == nil && slices.Equal(entryNames, names) {
+			return idx, nil
+		}
+	}
+
+	available, _ := listNamesOfNamedList(sequenceNode, keys)
+	return -1, newNamedEntryNotFoundError(keys, names, available)
+}
+
+// entryLines returns the line numbers of all entries in the list that are
+// identified by the identifier keys and names, which is more than one in case
+// of duplicate identifiers
+func entryLines(sequenceNode *yamlv3.Node, keys []string, names []string) []int {
+	var lines []int
+	for _, mappingNode := range sequenceNode.Content {
+		if mappingNode.Kind != yamlv3.MappingNode {
+			continue
+		}
+
+		if entryNames, err := getNameByIdentifier(mappingNode, keys); err == nil && slices.Equal(entryNames, names) {
+			lines = append(lines, mappingNode.Line)
 		}
 	}
 
-	return -1,
-		fmt.Errorf("there is no entry %s=%v in the list",
-			identifier,
-			name,
-		)
+	return lines
 }
 
-func listNamesOfNamedList(sequenceNode *yamlv3.Node, identifier string) ([]string, error) {
+// listNamesOfNamedList returns the names of all list entries, where the names
+// of entries identified by a composite identifier are comma separated
+func listNamesOfNamedList(sequenceNode *yamlv3.Node, keys []string) ([]string, error) {
 	result := make([]string, len(sequenceNode.Content))
 
 	for i, mappingNode := range sequenceNode.Content {
@@ -96,13 +98,72 @@ func listNamesOfNamedList(sequenceNode *yamlv3.Node, identifier string) ([]strin
 			return nil, &NoNamedEntryListError{}
 		}
 
-		v, err := getValueByKey(mappingNode, identifier)
+		names, err := getNameByIdentifier(mappingNode, keys)
 		if err != nil {
 			return nil, err
 		}
 
-		result[i] = v.Value
+		result[i] = strings.Join(names, ",")
 	}
 
 	return result, nil
 }
+
+// isIdentifierKey returns whether the provided key is one of the identifier keys
+func isIdentifierKey(keys []string, key string) bool {
+	return slices.Contains(keys, key)
+}
+
+// getNameByIdentifier returns the name of a named-entry list entry, which is
+// the value of the identifier key, or the values of all identifier keys in
+// case of a composite identifier, e.g. `80` and `TCP`
+func getNameByIdentifier(mappingNode *yamlv3.Node, keys []string) ([]string, error) {
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		value, err := getValueByKey(mappingNode, key)
+		if err != nil {
+			return nil, err
+		}
+
+		names[i] = value.Value
+	}
+
+	return names, nil
+}
+
+// namedListElement returns the path element of a named-entry list entry, which
+// uses the composite fields in case of more than one identifier key
+func namedListElement(keys []string, names []string) PathElement {
+	if len(keys) == 1 && len(names) == 1 {
+		return PathElement{Idx: -1, Key: keys[0], Name: names[0]}
+	}
+
+	return PathElement{Idx: -1, Keys: keys, Names: names}
+}
+
+// namedListEntryElement returns the path element of the provided named-entry
+// list entry using the identifier keys of the list
+func namedListEntryElement(mappingNode *yamlv3.Node, keys []string) PathElement {
+	names, err := getNameByIdentifier(mappingNode, keys)
+	if err != nil {
+		names = make([]string, len(keys))
+	}
+
+	return namedListElement(keys, names)
+}
+
+// formatIdentifierAndName returns the GoPatch style representation of a
+// named-entry list entry, e.g. `name=web` or `containerPort=80,protocol=TCP`
+func formatIdentifierAndName(keys []string, names []string) string {
+	pairs := make([]string, len(keys))
+	for i := range keys {
+		var name string
+		if i < len(names) {
+			name = names[i]
+		}
+
+		pairs[i] = fmt.Sprintf("%s=%s", keys[i], name)
+	}
+
+	return strings.Join(pairs, ",")
+}
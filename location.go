@@ -0,0 +1,138 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// LocatePath returns the line and column (both starting with 1) in the source
+// of the provided YAML document where the section referenced by the path
+// starts. For entries in a map, the position of the key is returned, since the
+// value of a map entry can start on a different line.
+func LocatePath(node *yamlv3.Node, path Path) (int, int, error) {
+	node = documentRoot(node)
+	if len(path.PathElements) == 0 {
+		return node.Line, node.Column, nil
+	}
+
+	parentPath := Path{
+		DocumentIdx:  path.DocumentIdx,
+		PathElements: path.PathElements[:len(path.PathElements)-1],
+	}
+
+	parent, err := grabByPath(node, parentPath)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	lastPathElement := path.PathElements[len(path.PathElements)-1]
+	if lastPathElement.isMapElement() && parent.Kind == yamlv3.MappingNode {
+		for i := 0; i < len(parent.Content); i += 2 {
+			if k := parent.Content[i]; k.Value == lastPathElement.Name {
+				return k.Line, k.Column, nil
+			}
+		}
+	}
+
+	target, err := grabByPath(parent, Path{PathElements: []PathElement{lastPathElement}})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return target.Line, target.Column, nil
+}
+
+// PathAt returns the path of the deepest node in the provided YAML document
+// that covers the given line and column (both starting with 1). Since YAML
+// nodes only carry their start position, a node is considered to cover
+// everything from its start up to the start of the node that follows it.
+func PathAt(node *yamlv3.Node, line int, column int) Path {
+	return pathAt(Path{}, documentRoot(node), line, column)
+}
+
+func pathAt(path Path, node *yamlv3.Node, line int, column int) Path {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		keys := make([]*yamlv3.Node, 0, len(node.Content)/2)
+		for i := 0; i < len(node.Content); i += 2 {
+			keys = append(keys, node.Content[i])
+		}
+
+		idx := lastCoveringNodeIdx(keys, line, column)
+		if idx < 0 {
+			return path
+		}
+
+		k, v := node.Content[idx*2], node.Content[idx*2+1]
+		path = NewPathWithNamedElement(path, k.Value)
+		if !isPositionBefore(v, line, column) {
+			return path
+		}
+
+		return pathAt(path, v, line, column)
+
+	case yamlv3.SequenceNode:
+		idx := lastCoveringNodeIdx(node.Content, line, column)
+		if idx < 0 {
+			return path
+		}
+
+		entry := node.Content[idx]
+		if identifier := DefaultIdentifierResolver.Identifier(path, node); identifier != nil {
+			entryPath := NewPathWithPathElement(path, namedListEntryElement(entry, identifier))
+
+			// The identifier itself is part of the named-entry list element, so
+			// the entry is the deepest section to refer to
+			result := pathAt(entryPath, entry, line, column)
+			if len(result.PathElements) == len(entryPath.PathElements)+1 &&
+				isIdentifierKey(identifier, result.PathElements[len(result.PathElements)-1].Name) {
+				return entryPath
+			}
+
+			return result
+		}
+
+		return pathAt(NewPathWithIndexedListElement(path, idx), entry, line, column)
+	}
+
+	return path
+}
+
+// lastCoveringNodeIdx returns the index of the last node in the list that
+// starts before the provided position. The first node on a line is considered
+// to cover the whole line to account for indicators like the dash of a block
+// sequence entry, which are not part of the node itself.
+func lastCoveringNodeIdx(nodes []*yamlv3.Node, line int, column int) int {
+	result := -1
+	for idx, node := range nodes {
+		firstOnLine := idx == 0 || nodes[idx-1].Line != node.Line
+		if isPositionBefore(node, line, column) || (node.Line == line && firstOnLine) {
+			result = idx
+		}
+	}
+
+	return result
+}
+
+func isPositionBefore(node *yamlv3.Node, line int, column int) bool {
+	return node.Line < line || (node.Line == line && node.Column <= column)
+}
@@ -0,0 +1,97 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+var _ = Describe("Source locations", func() {
+	locate := func(pathString string) (int, int) {
+		path, err := ParseGoPatchStylePathString(pathString)
+		Expect(err).ToNot(HaveOccurred())
+
+		line, column, err := LocatePath(getExampleDocument(), path)
+		Expect(err).ToNot(HaveOccurred())
+
+		return line, column
+	}
+
+	Context("Resolving paths to line and column", func() {
+		It("should return the position of a map key", func() {
+			line, column := locate("/yaml/structure/somekey")
+			Expect(line).To(Equal(5))
+			Expect(column).To(Equal(5))
+		})
+
+		It("should return the position of a named-entry list entry", func() {
+			line, column := locate("/list/name=one")
+			Expect(line).To(Equal(9))
+			Expect(column).To(Equal(3))
+		})
+
+		It("should return the position of a simple list entry", func() {
+			line, column := locate("/simpleList/1")
+			Expect(line).To(Equal(15))
+			Expect(column).To(Equal(3))
+		})
+
+		It("should fail for paths that are not in the document", func() {
+			path, err := ParseGoPatchStylePathString("/yaml/nope")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = LocatePath(getExampleDocument(), path)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should not fail for empty documents", func() {
+			path, err := ParseGoPatchStylePathString("/yaml")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = LocatePath(&yamlv3.Node{Kind: yamlv3.DocumentNode}, path)
+			Expect(err).To(HaveOccurred())
+
+			Expect(PathAt(&yamlv3.Node{Kind: yamlv3.DocumentNode}, 1, 1).PathElements).To(BeEmpty())
+		})
+	})
+
+	Context("Resolving line and column to paths", func() {
+		It("should return the deepest path at the position", func() {
+			Expect(PathAt(getExampleDocument(), 5, 14).String()).To(Equal("/yaml/structure/somekey"))
+			Expect(PathAt(getExampleDocument(), 4, 3).String()).To(Equal("/yaml/structure"))
+			Expect(PathAt(getExampleDocument(), 10, 14).String()).To(Equal("/list/name=one/somekey"))
+			Expect(PathAt(getExampleDocument(), 9, 1).String()).To(Equal("/list/name=one"))
+			Expect(PathAt(getExampleDocument(), 15, 1).String()).To(Equal("/simpleList/1"))
+		})
+
+		It("should return paths that can be located again", func() {
+			for _, pathString := range []string{"/yaml/structure/somekey", "/list/name=one/somekey", "/simpleList/0"} {
+				line, column := locate(pathString)
+				Expect(PathAt(getExampleDocument(), line, column).String()).To(Equal(pathString))
+			}
+		})
+	})
+})
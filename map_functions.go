@@ -55,3 +55,16 @@ func getValueByKey(mappingNode *yamlv3.Node, key string) (*yamlv3.Node, error) {
 		AvailableKeys: listKeys(mappingNode),
 	}
 }
+
+// keyLines returns the line numbers of all occurrences of the provided key in
+// the mapping node, which is more than one in case of duplicate keys
+func keyLines(mappingNode *yamlv3.Node, key string) []int {
+	var lines []int
+	for i := 0; i < len(mappingNode.Content); i += 2 {
+		if k := mappingNode.Content[i]; k.Value == key {
+			lines = append(lines, k.Line)
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,287 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"fmt"
+	"slices"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// MergeConflict describes a section that was changed differently in both
+// versions that are merged. Base, ours, or theirs are nil if the section does
+// not exist in the respective version, for example because it was removed.
+type MergeConflict struct {
+	Path   Path
+	Base   *yamlv3.Node
+	Ours   *yamlv3.Node
+	Theirs *yamlv3.Node
+}
+
+func (conflict MergeConflict) String() string {
+	return fmt.Sprintf("conflict in %s", conflict.Path.ToGoPatchStyle())
+}
+
+// Merge3 performs a three-way merge of two versions (ours and theirs) that
+// were both derived from a common base version. Changes that were made in only
+// one of the versions, or identically in both, are taken over into the result.
+// Maps are merged key by key and named-entry lists entry by entry using the
+// identifier of the list. Sections that were changed differently in both
+// versions are reported as conflicts, the result contains our version of these
+// sections. Neither of the input nodes is modified.
+func Merge3(base *yamlv3.Node, ours *yamlv3.Node, theirs *yamlv3.Node) (*yamlv3.Node, []MergeConflict) {
+	root := func(node *yamlv3.Node) *yamlv3.Node {
+		if node == nil {
+			return nil
+		}
+
+		return documentRoot(node)
+	}
+
+	merger := &merger{}
+	result := merger.merge(Path{}, root(base), root(ours), root(theirs))
+
+	if result != nil && ours != nil && ours.Kind == yamlv3.DocumentNode {
+		result = &yamlv3.Node{
+			Kind:        yamlv3.DocumentNode,
+			HeadComment: ours.HeadComment,
+			FootComment: ours.FootComment,
+			Content:     []*yamlv3.Node{result},
+		}
+	}
+
+	return result, merger.conflicts
+}
+
+type merger struct {
+	conflicts []MergeConflict
+}
+
+func (m *merger) merge(path Path, base *yamlv3.Node, ours *yamlv3.Node, theirs *yamlv3.Node) *yamlv3.Node {
+	base, ours, theirs = resolveAlias(base), resolveAlias(ours), resolveAlias(theirs)
+
+	switch {
+	case sameNode(ours, theirs), sameNode(base, theirs):
+		return copyNode(ours)
+
+	case sameNode(base, ours):
+		return copyNode(theirs)
+
+	case isKind(ours, yamlv3.MappingNode) && isKind(theirs, yamlv3.MappingNode):
+		return m.mergeMaps(path, kindOrNil(base, yamlv3.MappingNode), ours, theirs)
+
+	case isKind(ours, yamlv3.SequenceNode) && isKind(theirs, yamlv3.SequenceNode):
+		identifier := DefaultIdentifierResolver.Identifier(path, ours)
+		if identifier != nil && slices.Equal(identifier, DefaultIdentifierResolver.Identifier(path, theirs)) {
+			return m.mergeNamedLists(path, kindOrNil(base, yamlv3.SequenceNode), ours, theirs, identifier)
+		}
+	}
+
+	// Lists without identifier and scalars cannot be merged any further
+	m.conflicts = append(m.conflicts, MergeConflict{Path: path, Base: base, Ours: ours, Theirs: theirs})
+	return copyNode(ours)
+}
+
+func (m *merger) mergeMaps(path Path, base *yamlv3.Node, ours *yamlv3.Node, theirs *yamlv3.Node) *yamlv3.Node {
+	result := shallowCopyNode(ours)
+
+	valueOf := func(mappingNode *yamlv3.Node, key string) *yamlv3.Node {
+		if mappingNode == nil {
+			return nil
+		}
+
+		value, _ := getValueByKey(mappingNode, key)
+		return value
+	}
+
+	add := func(keyNode *yamlv3.Node) {
+		key := keyNode.Value
+		value := m.merge(NewPathWithNamedElement(path, key), valueOf(base, key), valueOf(ours, key), valueOf(theirs, key))
+		if value != nil {
+			result.Content = append(result.Content, copyNode(keyNode), value)
+		}
+	}
+
+	for i := 0; i < len(ours.Content); i += 2 {
+		add(ours.Content[i])
+	}
+
+	for i := 0; i < len(theirs.Content); i += 2 {
+		if valueOf(ours, theirs.Content[i].Value) == nil {
+			add(theirs.Content[i])
+		}
+	}
+
+	return result
+}
+
+func (m *merger) mergeNamedLists(path Path, base *yamlv3.Node, ours *yamlv3.Node, theirs *yamlv3.Node, identifier []string) *yamlv3.Node {
+	var baseNames []string
+	if base != nil {
+		baseNames = entryNames(base, identifier)
+	}
+
+	ourNames, theirNames := entryNames(ours, identifier), entryNames(theirs, identifier)
+
+	// Entries cannot be matched if their names are not unique
+	if hasDuplicates(baseNames) || hasDuplicates(ourNames) || hasDuplicates(theirNames) {
+		m.conflicts = append(m.conflicts, MergeConflict{Path: path, Base: base, Ours: ours, Theirs: theirs})
+		return copyNode(ours)
+	}
+
+	baseLookup, ourLookup, theirLookup := lookupMap(baseNames), lookupMap(ourNames), lookupMap(theirNames)
+
+	entryOf := func(sequenceNode *yamlv3.Node, lookup map[string]int, name string) *yamlv3.Node {
+		if idx, ok := lookup[name]; ok && name != "" {
+			return sequenceNode.Content[idx]
+		}
+
+		return nil
+	}
+
+	mergeEntry := func(name string) *yamlv3.Node {
+		base, ours, theirs := entryOf(base, baseLookup, name), entryOf(ours, ourLookup, name), entryOf(theirs, theirLookup, name)
+
+		var element PathElement
+		for _, entry := range []*yamlv3.Node{ours, theirs, base} {
+			if entry != nil {
+				element = namedListEntryElement(entry, identifier)
+				break
+			}
+		}
+
+		return m.merge(NewPathWithPathElement(path, element), base, ours, theirs)
+	}
+
+	entries := map[string]*yamlv3.Node{}
+	var order []string
+	for _, name := range ourNames {
+		if entry := mergeEntry(name); entry != nil {
+			entries[name] = entry
+			order = append(order, name)
+		}
+	}
+
+	// Entries only known to their version are inserted after the entry that
+	// precedes them in their version, or at the start if there is none, which
+	// is the last of their entries so far that is part of the result
+	followers, predecessor := map[string][]string{}, ""
+	for _, name := range theirNames {
+		if _, ok := ourLookup[name]; ok {
+			if _, ok := entries[name]; ok {
+				predecessor = name
+			}
+
+			continue
+		}
+
+		if entry := mergeEntry(name); entry != nil {
+			entries[name] = entry
+			followers[predecessor] = append(followers[predecessor], name)
+			predecessor = name
+		}
+	}
+
+	result := shallowCopyNode(ours)
+
+	var add func(name string)
+	add = func(name string) {
+		result.Content = append(result.Content, entries[name])
+		for _, follower := range followers[name] {
+			add(follower)
+		}
+	}
+
+	for _, name := range followers[""] {
+		add(name)
+	}
+
+	for _, name := range order {
+		add(name)
+	}
+
+	return result
+}
+
+func hasDuplicates(names []string) bool {
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			return true
+		}
+
+		seen[name] = struct{}{}
+	}
+
+	return false
+}
+
+func sameNode(a *yamlv3.Node, b *yamlv3.Node) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return NodesEqual(a, b, EqualityOptions{})
+}
+
+func isKind(node *yamlv3.Node, kind yamlv3.Kind) bool {
+	return node != nil && node.Kind == kind
+}
+
+func kindOrNil(node *yamlv3.Node, kind yamlv3.Kind) *yamlv3.Node {
+	if isKind(node, kind) {
+		return node
+	}
+
+	return nil
+}
+
+func resolveAlias(node *yamlv3.Node) *yamlv3.Node {
+	if node != nil && node.Kind == yamlv3.AliasNode && node.Alias != nil {
+		return node.Alias
+	}
+
+	return node
+}
+
+// shallowCopyNode returns a copy of the node without its content
+func shallowCopyNode(node *yamlv3.Node) *yamlv3.Node {
+	result := *node
+	result.Content = nil
+	return &result
+}
+
+// copyNode returns a deep copy of the node with all aliases resolved
+func copyNode(node *yamlv3.Node) *yamlv3.Node {
+	node = resolveAlias(node)
+	if node == nil {
+		return nil
+	}
+
+	result := shallowCopyNode(node)
+	result.Anchor = ""
+	for _, entry := range node.Content {
+		result.Content = append(result.Content, copyNode(entry))
+	}
+
+	return result
+}
@@ -0,0 +1,193 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+
+	. "github.com/gonvenience/ytbx"
+)
+
+var _ = Describe("Three-way merge", func() {
+	Context("Merging maps", func() {
+		It("should take over changes made in only one version", func() {
+			result, conflicts := Merge3(
+				yml(`{name: app, version: 1, replicas: 1, debug: false}`),
+				yml(`{name: app, version: 2, replicas: 1, debug: false, extra: yes}`),
+				yml(`{name: app, version: 1, replicas: 3}`),
+			)
+
+			Expect(conflicts).To(BeEmpty())
+			Expect(result).To(BeAsNode(yml(`{name: app, version: 2, replicas: 3, extra: yes}`)))
+		})
+
+		It("should accept identical changes in both versions", func() {
+			result, conflicts := Merge3(
+				yml(`{version: 1}`),
+				yml(`{version: 2, added: foo}`),
+				yml(`{version: 2, added: foo}`),
+			)
+
+			Expect(conflicts).To(BeEmpty())
+			Expect(result).To(BeAsNode(yml(`{version: 2, added: foo}`)))
+		})
+
+		It("should merge nested maps key by key", func() {
+			result, conflicts := Merge3(
+				yml(`{spec: {a: 1, b: 1}}`),
+				yml(`{spec: {a: 2, b: 1}}`),
+				yml(`{spec: {a: 1, b: 2, c: 3}}`),
+			)
+
+			Expect(conflicts).To(BeEmpty())
+			Expect(result).To(BeAsNode(yml(`{spec: {a: 2, b: 2, c: 3}}`)))
+		})
+
+		It("should report conflicting changes and keep our version", func() {
+			result, conflicts := Merge3(
+				yml(`{spec: {a: 1, b: 1}}`),
+				yml(`{spec: {a: 2, b: 2}}`),
+				yml(`{spec: {a: 3, b: 2}}`),
+			)
+
+			Expect(result).To(BeAsNode(yml(`{spec: {a: 2, b: 2}}`)))
+			Expect(conflicts).To(HaveLen(1))
+			Expect(conflicts[0].Path.String()).To(Equal("/spec/a"))
+			Expect(conflicts[0].String()).To(Equal("conflict in /spec/a"))
+			Expect(conflicts[0].Base).To(BeAsNode(yml(`{a: 1}`).Content[1]))
+			Expect(conflicts[0].Ours).To(BeAsNode(yml(`{a: 2}`).Content[1]))
+			Expect(conflicts[0].Theirs).To(BeAsNode(yml(`{a: 3}`).Content[1]))
+		})
+
+		It("should report a removal in one version and a change in the other as conflict", func() {
+			result, conflicts := Merge3(
+				yml(`{a: 1, b: 1}`),
+				yml(`{b: 1}`),
+				yml(`{a: 2, b: 1}`),
+			)
+
+			Expect(result).To(BeAsNode(yml(`{b: 1}`)))
+			Expect(conflicts).To(HaveLen(1))
+			Expect(conflicts[0].Path.String()).To(Equal("/a"))
+			Expect(conflicts[0].Ours).To(BeNil())
+		})
+
+		It("should report keys added differently in both versions as conflict", func() {
+			_, conflicts := Merge3(
+				yml(`{a: 1}`),
+				yml(`{a: 1, b: {x: 1, y: 1}}`),
+				yml(`{a: 1, b: {x: 2, y: 1}}`),
+			)
+
+			Expect(conflicts).To(HaveLen(1))
+			Expect(conflicts[0].Path.String()).To(Equal("/b/x"))
+			Expect(conflicts[0].Base).To(BeNil())
+		})
+	})
+
+	Context("Merging lists", func() {
+		It("should merge named-entry lists entry by entry", func() {
+			result, conflicts := Merge3(
+				yml(`{jobs: [{name: a, instances: 1}, {name: b, instances: 1}, {name: c, instances: 1}]}`),
+				yml(`{jobs: [{name: a, instances: 2}, {name: b, instances: 1}, {name: c, instances: 1}, {name: d, instances: 1}]}`),
+				yml(`{jobs: [{name: a, instances: 1}, {name: x, instances: 1}, {name: b, instances: 5}]}`),
+			)
+
+			Expect(conflicts).To(BeEmpty())
+			Expect(result).To(BeAsNode(yml(`{jobs: [{name: a, instances: 2}, {name: x, instances: 1}, {name: b, instances: 5}, {name: d, instances: 1}]}`)))
+		})
+
+		It("should report conflicts in named-entry lists using the entry name", func() {
+			_, conflicts := Merge3(
+				yml(`{jobs: [{name: a, instances: 1}]}`),
+				yml(`{jobs: [{name: a, instances: 2}]}`),
+				yml(`{jobs: [{name: a, instances: 3}]}`),
+			)
+
+			Expect(conflicts).To(HaveLen(1))
+			Expect(conflicts[0].Path.String()).To(Equal("/jobs/name=a/instances"))
+		})
+
+		It("should report named-entry lists with duplicate names as conflict", func() {
+			result, conflicts := Merge3(
+				yml(`{jobs: [{name: a, instances: 1}]}`),
+				yml(`{jobs: [{name: a, instances: 1}, {name: a, instances: 2}]}`),
+				yml(`{jobs: [{name: a, instances: 3}]}`),
+			)
+
+			Expect(conflicts).To(HaveLen(1))
+			Expect(conflicts[0].Path.String()).To(Equal("/jobs"))
+			Expect(result).To(BeAsNode(yml(`{jobs: [{name: a, instances: 1}, {name: a, instances: 2}]}`)))
+		})
+
+		It("should insert consecutive entries added in their version in order", func() {
+			result, conflicts := Merge3(
+				yml(`{jobs: [{name: a}, {name: b}]}`),
+				yml(`{jobs: [{name: b}, {name: a}]}`),
+				yml(`{jobs: [{name: w}, {name: a}, {name: x}, {name: y}, {name: b}, {name: z}]}`),
+			)
+
+			Expect(conflicts).To(BeEmpty())
+			Expect(result).To(BeAsNode(yml(`{jobs: [{name: w}, {name: b}, {name: z}, {name: a}, {name: x}, {name: y}]}`)))
+		})
+
+		It("should take over lists without identifier changed in only one version", func() {
+			result, conflicts := Merge3(
+				yml(`{args: [a, b], other: 1}`),
+				yml(`{args: [a, b], other: 2}`),
+				yml(`{args: [b, a, c], other: 1}`),
+			)
+
+			Expect(conflicts).To(BeEmpty())
+			Expect(result).To(BeAsNode(yml(`{args: [b, a, c], other: 2}`)))
+		})
+
+		It("should report lists without identifier changed in both versions as conflict", func() {
+			_, conflicts := Merge3(
+				yml(`{args: [a, b]}`),
+				yml(`{args: [a, b, c]}`),
+				yml(`{args: [a]}`),
+			)
+
+			Expect(conflicts).To(HaveLen(1))
+			Expect(conflicts[0].Path.String()).To(Equal("/args"))
+		})
+	})
+
+	Context("Merging documents", func() {
+		It("should support document nodes and not modify the input", func() {
+			base := singleDoc("a: 1\nb: 1\n")
+			ours := singleDoc("a: 2\nb: 1\n")
+			theirs := singleDoc("a: 1\nb: 2\n")
+
+			result, conflicts := Merge3(base, ours, theirs)
+			Expect(conflicts).To(BeEmpty())
+			Expect(result.Kind).To(Equal(yamlv3.DocumentNode))
+			Expect(result.Content[0]).To(BeAsNode(yml(`{a: 2, b: 2}`)))
+
+			Expect(ours.Content[0]).To(BeAsNode(yml(`{a: 2, b: 1}`)))
+			Expect(theirs.Content[0]).To(BeAsNode(yml(`{a: 1, b: 2}`)))
+		})
+	})
+})
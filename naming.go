@@ -0,0 +1,124 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"fmt"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// DocumentNamer derives a descriptive name of a document from its content. It
+// returns false if the document is not of a type the namer knows about.
+type DocumentNamer func(document *yamlv3.Node) (string, bool)
+
+// DefaultDocumentNamers is the list of document naming strategies that is
+// used when no explicit strategies are provided to `NameDocuments`
+var DefaultDocumentNamers = []DocumentNamer{
+	KubernetesDocumentNamer,
+	BOSHDocumentNamer,
+	ConcourseDocumentNamer,
+}
+
+// NameDocuments returns a copy of the input file with a name for every
+// document. The first naming strategy that knows how to name a document is
+// used. Documents that cannot be named by any strategy keep their existing
+// name if there is one for each document, or are named by their index.
+func NameDocuments(inputFile InputFile, namers ...DocumentNamer) InputFile {
+	if len(namers) == 0 {
+		namers = DefaultDocumentNamers
+	}
+
+	names := make([]string, len(inputFile.Documents))
+	for idx, document := range inputFile.Documents {
+		names[idx] = nameDocument(inputFile, idx, document, namers)
+	}
+
+	return InputFile{
+		Location:  inputFile.Location,
+		Note:      inputFile.Note,
+		Documents: inputFile.Documents,
+		Names:     names,
+	}
+}
+
+func nameDocument(inputFile InputFile, idx int, document *yamlv3.Node, namers []DocumentNamer) string {
+	for _, namer := range namers {
+		if name, ok := namer(document); ok {
+			return name
+		}
+	}
+
+	if len(inputFile.Names) == len(inputFile.Documents) {
+		return inputFile.Names[idx]
+	}
+
+	// Note: human style counting that starts with 1
+	return fmt.Sprintf("document #%d", idx+1)
+}
+
+// KubernetesDocumentNamer names Kubernetes resources using kind, namespace,
+// and name, for example `Deployment/default/web`. Cluster scoped resources
+// without a namespace are named using kind and name only. This is the same
+// naming that `ExpandKubernetesLists` uses for the expanded list items.
+func KubernetesDocumentNamer(document *yamlv3.Node) (string, bool) {
+	return kubernetesResourceName(document)
+}
+
+// BOSHDocumentNamer names BOSH deployment manifests using the deployment name,
+// for example `BOSH deployment concourse`
+func BOSHDocumentNamer(document *yamlv3.Node) (string, bool) {
+	root := documentRoot(document)
+	if root.Kind != yamlv3.MappingNode {
+		return "", false
+	}
+
+	name, err := getValueByKey(root, "name")
+	if err != nil {
+		return "", false
+	}
+
+	if countCommonKeys(listKeys(root), []string{"instance_groups", "releases", "stemcells"}) == 0 {
+		return "", false
+	}
+
+	return fmt.Sprintf("BOSH deployment %s", name.Value), true
+}
+
+// ConcourseDocumentNamer names Concourse pipeline configurations, which do
+// not have a name of their own, and Concourse task configurations
+func ConcourseDocumentNamer(document *yamlv3.Node) (string, bool) {
+	root := documentRoot(document)
+	if root.Kind != yamlv3.MappingNode {
+		return "", false
+	}
+
+	keys := listKeys(root)
+	switch {
+	case countCommonKeys(keys, []string{"jobs", "resources"}) == 2:
+		return "Concourse pipeline", true
+
+	case countCommonKeys(keys, []string{"platform", "run"}) == 2:
+		return "Concourse task", true
+	}
+
+	return "", false
+}
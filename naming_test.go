@@ -0,0 +1,75 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+var _ = Describe("Naming documents by content", func() {
+	Context("Using the default naming strategies", func() {
+		It("should name Kubernetes resources by kind, namespace, and name", func() {
+			input, err := LoadFile(assets("kubernetes", "list.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			named := NameDocuments(ExpandKubernetesLists(input))
+			Expect(named.Names).To(Equal([]string{
+				"Deployment/default/web",
+				"Service/default/web",
+				"Namespace/default",
+				"ConfigMap/default/settings",
+			}))
+
+			path := Path{Root: &named, DocumentIdx: 1}
+			Expect(path.RootDescription()).To(Equal("Service/default/web"))
+		})
+
+		It("should name BOSH deployment manifests by the deployment name", func() {
+			input, err := LoadFile(assets("bosh-yaml", "manifest.yml"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(NameDocuments(input).Names).To(Equal([]string{"BOSH deployment concourse"}))
+		})
+
+		It("should fall back to the document index for unknown documents", func() {
+			input, err := LoadFile(assets("examples", "types.yml"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(NameDocuments(input).Names).To(Equal([]string{"document #1"}))
+		})
+	})
+
+	Context("Using custom naming strategies", func() {
+		It("should use the first strategy that can name the document", func() {
+			input, err := LoadFile(assets("examples", "types.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			custom := func(document *yamlv3.Node) (string, bool) {
+				return "types example", true
+			}
+
+			Expect(NameDocuments(input, KubernetesDocumentNamer, custom).Names).To(Equal([]string{"types example"}))
+		})
+	})
+})
@@ -0,0 +1,188 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// OverrideOptions controls how an overlay file is compared with its base file
+type OverrideOptions struct {
+	// Pairing defines which documents of the base and overlay are compared
+	Pairing DocumentPairing
+
+	// Namers are used to name documents when pairing them by name, the
+	// default document namers are used if none are provided
+	Namers []DocumentNamer
+
+	// Equality controls when overlay values are considered to be the same as
+	// the base values
+	Equality EqualityOptions
+
+	// MergeNamedLists assumes that named-entry lists of the overlay are merged
+	// into the base entry by entry, like Kubernetes strategic merge patches do
+	// with containers, so that list entries can be redundant on their own
+	MergeNamedLists bool
+
+	// Prune removes the redundant overrides from the overlay documents
+	Prune bool
+}
+
+// RedundantOverrides returns the paths of the overlay that do not change
+// anything, because their value is the same as in the base, where values are
+// compared using `NodesEqual`. Maps are compared key by key, assuming that the
+// overlay is merged into the base, so that a map is redundant if all its keys
+// are. Lists are assumed to replace the list of the base and are therefore only
+// redundant as a whole, unless named-entry lists are merged. With the prune
+// option, the redundant overrides are removed from the overlay documents.
+func RedundantOverrides(base InputFile, overlay InputFile, opts OverrideOptions) ([]Path, error) {
+	var result []Path
+	for _, pair := range pairDocuments(base, overlay, CompareOptions{Pairing: opts.Pairing, Namers: opts.Namers}) {
+		root := Path{Root: &overlay, DocumentIdx: pair.ToIdx}
+		if opts.Pairing == PairByName {
+			root.DocumentName = pair.Name
+		}
+
+		baseRoot, overlayRoot := documentRoot(base.Documents[pair.FromIdx]), documentRoot(overlay.Documents[pair.ToIdx])
+
+		// The root of a document cannot be deleted, a redundant document is
+		// reported using all of its top-level keys instead
+		var paths []Path
+		if redundant, subPaths := redundantOverrides(root, baseRoot, overlayRoot, opts); !redundant {
+			paths = subPaths
+
+		} else if overlayRoot.Kind == yamlv3.MappingNode {
+			for i := 0; i < len(overlayRoot.Content); i += 2 {
+				paths = append(paths, NewPathWithNamedElement(root, overlayRoot.Content[i].Value))
+			}
+		}
+
+		if opts.Prune {
+			for _, path := range paths {
+				if _, err := deleteFromDocument(overlay, path); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		result = append(result, paths...)
+	}
+
+	return result, nil
+}
+
+// redundantOverrides returns whether the overlay is redundant as a whole, or
+// otherwise the paths of the redundant overrides inside of it
+func redundantOverrides(path Path, base *yamlv3.Node, overlay *yamlv3.Node, opts OverrideOptions) (bool, []Path) {
+	if NodesEqual(base, overlay, opts.Equality) {
+		return true, nil
+	}
+
+	base, overlay = resolveAlias(base), resolveAlias(overlay)
+	if opts.MergeNamedLists && base.Kind == yamlv3.SequenceNode && overlay.Kind == yamlv3.SequenceNode {
+		return redundantListEntries(path, base, overlay, opts)
+	}
+
+	if base.Kind != yamlv3.MappingNode || overlay.Kind != yamlv3.MappingNode || len(overlay.Content) == 0 {
+		return false, nil
+	}
+
+	var paths []Path
+	baseValues := valuesByKey(base)
+	for i := 0; i < len(overlay.Content); i += 2 {
+		key := overlay.Content[i].Value
+		keyPath := NewPathWithNamedElement(path, key)
+
+		baseValue, ok := baseValues[key]
+		if !ok {
+			continue
+		}
+
+		if redundant, subPaths := redundantOverrides(keyPath, baseValue, overlay.Content[i+1], opts); redundant {
+			paths = append(paths, keyPath)
+		} else {
+			paths = append(paths, subPaths...)
+		}
+	}
+
+	// An overlay map is redundant if all of its keys are
+	if len(paths)*2 == len(overlay.Content) && allDirectChildren(path, paths) {
+		return true, nil
+	}
+
+	return false, paths
+}
+
+// redundantListEntries returns whether the overlay named-entry list is
+// redundant as a whole, or otherwise the paths of the redundant overrides in
+// its entries, where the identifier keys of an entry are never redundant on
+// their own, since they are required to merge the entry
+func redundantListEntries(path Path, base *yamlv3.Node, overlay *yamlv3.Node, opts OverrideOptions) (bool, []Path) {
+	identifier := DefaultIdentifierResolver.Identifier(path, overlay)
+	if identifier == nil || len(overlay.Content) == 0 {
+		return false, nil
+	}
+
+	var paths []Path
+	for _, entry := range overlay.Content {
+		names, err := getNameByIdentifier(entry, identifier)
+		if err != nil {
+			continue
+		}
+
+		baseEntry, ok := getEntryFromNamedList(base, identifier, names)
+		if !ok {
+			continue
+		}
+
+		entryPath := NewPathWithPathElement(path, namedListElement(identifier, names))
+		redundant, subPaths := redundantOverrides(entryPath, baseEntry, entry, opts)
+		if redundant {
+			paths = append(paths, entryPath)
+			continue
+		}
+
+		for _, subPath := range subPaths {
+			if allDirectChildren(entryPath, []Path{subPath}) && isIdentifierKey(identifier, subPath.PathElements[len(subPath.PathElements)-1].Name) {
+				continue
+			}
+
+			paths = append(paths, subPath)
+		}
+	}
+
+	// An overlay list is redundant if all of its entries are
+	if len(paths) == len(overlay.Content) && allDirectChildren(path, paths) {
+		return true, nil
+	}
+
+	return false, paths
+}
+
+func allDirectChildren(parent Path, paths []Path) bool {
+	for _, path := range paths {
+		if len(path.PathElements) != len(parent.PathElements)+1 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,135 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+)
+
+var _ = Describe("Redundant overrides", func() {
+	pathStrings := func(paths []Path) []string {
+		result := []string{}
+		for _, path := range paths {
+			result = append(result, path.String())
+		}
+
+		return result
+	}
+
+	redundantOverrides := func(base InputFile, overlay InputFile, opts OverrideOptions) []string {
+		paths, err := RedundantOverrides(base, overlay, opts)
+		Expect(err).ToNot(HaveOccurred())
+		return pathStrings(paths)
+	}
+
+	var base InputFile
+
+	BeforeEach(func() {
+		base = inputFile(`{replicas: 2, image: {repository: nginx, tag: "1.25"}, resources: {cpu: 100m, memory: 128Mi}, args: [--verbose, --port=80]}`)
+	})
+
+	Context("Finding redundant overrides", func() {
+		It("should report overlay values that are the same as in the base", func() {
+			overlay := inputFile(`{replicas: 2, image: {repository: nginx, tag: "1.26"}}`)
+			Expect(redundantOverrides(base, overlay, OverrideOptions{})).To(Equal([]string{"/replicas", "/image/repository"}))
+		})
+
+		It("should report a map as a whole if all of its keys are redundant", func() {
+			overlay := inputFile(`{replicas: 3, resources: {memory: 128Mi}}`)
+			Expect(redundantOverrides(base, overlay, OverrideOptions{})).To(Equal([]string{"/resources"}))
+		})
+
+		It("should only report lists that are the same as a whole", func() {
+			Expect(redundantOverrides(base, inputFile(`{args: [--verbose]}`), OverrideOptions{})).To(BeEmpty())
+			Expect(redundantOverrides(base, inputFile(`{args: [--verbose, --port=80]}`), OverrideOptions{})).To(Equal([]string{"/args"}))
+		})
+
+		It("should not report keys that are not in the base", func() {
+			overlay := inputFile(`{image: {tag: "1.25", pullPolicy: Always}}`)
+			Expect(redundantOverrides(base, overlay, OverrideOptions{})).To(Equal([]string{"/image/tag"}))
+		})
+
+		It("should compare values semantically", func() {
+			overlay := inputFile(`{replicas: 2.0, image: {tag: '1.25', pullPolicy: Always}}`)
+			Expect(redundantOverrides(base, overlay, OverrideOptions{})).To(Equal([]string{"/image/tag"}))
+			Expect(redundantOverrides(base, overlay, OverrideOptions{Equality: EqualityOptions{NormalizeNumbers: true}})).To(Equal([]string{"/replicas", "/image/tag"}))
+		})
+
+		It("should report all top-level keys of a redundant document", func() {
+			overlay := inputFile(`{replicas: 2, image: {tag: "1.25"}}`)
+			Expect(redundantOverrides(base, overlay, OverrideOptions{})).To(Equal([]string{"/replicas", "/image"}))
+		})
+	})
+
+	Context("Pruning redundant overrides", func() {
+		It("should remove the redundant overrides from the overlay", func() {
+			overlay := inputFile(`{replicas: 2, image: {repository: nginx, tag: "1.26"}, resources: {memory: 128Mi}, args: [--debug]}`)
+			Expect(redundantOverrides(base, overlay, OverrideOptions{Prune: true})).To(Equal([]string{"/replicas", "/image/repository", "/resources"}))
+			Expect(overlay.Documents[0]).To(BeAsNode(singleDoc(`{image: {tag: "1.26"}, args: [--debug]}`)))
+		})
+
+		It("should prune documents that are paired by name", func() {
+			base, err := LoadFile(assets("testbed", "multi_a.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			overlay, err := LoadFile(assets("testbed", "multi_b.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			paths := redundantOverrides(base, overlay, OverrideOptions{Pairing: PairByName, Prune: true})
+			Expect(paths).To(ContainElement("/data/mode"))
+			Expect(paths).ToNot(ContainElement("/data/level"))
+
+			configMap, err := Grab(overlay.Documents[1], "/data")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configMap).To(BeAsNode(yml(`{level: info}`)))
+		})
+
+		It("should prune redundant entries of named-entry lists", func() {
+			defer func(resolver *IdentifierResolver) { DefaultIdentifierResolver = resolver }(DefaultIdentifierResolver)
+			DefaultIdentifierResolver = NewIdentifierResolver("name")
+			DefaultIdentifierResolver.AddPathIdentifier("**/containers/*/ports", "containerPort", "protocol")
+
+			base := inputFile(`{containers: [{name: web, image: nginx, ports: [{containerPort: 80, protocol: TCP, hostPort: 8080}, {containerPort: 53, protocol: UDP}]}, {name: sidecar, image: envoy}]}`)
+			overlay := inputFile(`{containers: [{name: web, image: "nginx:1.26", ports: [{containerPort: 80, protocol: TCP, hostPort: 8080}, {containerPort: 53, protocol: UDP, hostPort: 5353}]}, {name: sidecar, image: envoy}]}`)
+
+			Expect(redundantOverrides(base, overlay, OverrideOptions{Prune: true})).To(BeEmpty())
+			Expect(redundantOverrides(base, overlay, OverrideOptions{MergeNamedLists: true, Prune: true})).To(Equal([]string{
+				"/containers/name=web/ports/containerPort=80,protocol=TCP",
+				"/containers/name=sidecar",
+			}))
+			Expect(overlay.Documents[0]).To(BeAsNode(singleDoc(`{containers: [{name: web, image: "nginx:1.26", ports: [{containerPort: 53, protocol: UDP, hostPort: 5353}]}]}`)))
+		})
+
+		It("should prune keys that contain a slash", func() {
+			base := inputFile(`{a/b: 1, c: 2}`)
+			overlay := inputFile(`{a/b: 1, c: 3}`)
+
+			paths, err := RedundantOverrides(base, overlay, OverrideOptions{Prune: true})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(paths).To(HaveLen(1))
+			Expect(paths[0].PathElements[0].Name).To(Equal("a/b"))
+			Expect(overlay.Documents[0]).To(BeAsNode(singleDoc(`{c: 3}`)))
+		})
+	})
+})
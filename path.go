@@ -22,7 +22,6 @@ package ytbx
 
 import (
 	"fmt"
-	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -32,6 +31,8 @@ import (
 
 var dotRegEx = regexp.MustCompile(`^((\d+):)?(.*)$`)
 
+var documentReferenceRegEx = regexp.MustCompile(`^#(\\/|[^/])+/`)
+
 // PathStyle is a custom type for supported path styles
 type PathStyle int
 
@@ -42,6 +43,19 @@ const (
 	GoPatchStyle
 )
 
+func (style PathStyle) String() string {
+	switch style {
+	case DotStyle:
+		return "Dot"
+
+	case GoPatchStyle:
+		return "GoPatch"
+
+	default:
+		return "unknown"
+	}
+}
+
 // Path points to a section in a data structure by using names to identify the
 // location.
 // Example:
@@ -54,51 +68,92 @@ const (
 type Path struct {
 	Root         *InputFile
 	DocumentIdx  int
+	DocumentName string
 	PathElements []PathElement
+
+	// referenced is set for paths that were parsed from a path string with an
+	// explicit document reference, which is then part of the string form
+	referenced bool
 }
 
 // PathElement represents one part of a path, which can either address an entry
 // in a map (by name), a named-entry list entry (key and name), or an entry in a
-// list (by index).
+// list (by index). Named-entry list entries that are identified by a composite
+// identifier use the keys and names instead, for example keys `containerPort`
+// and `protocol` with names `80` and `TCP`.
 type PathElement struct {
 	Idx  int
 	Key  string
 	Name string
+
+	Keys  []string
+	Names []string
 }
 
 func (path Path) String() string {
 	return path.ToGoPatchStyle()
 }
 
-// ToGoPatchStyle returns the path as a GoPatch style string.
+// ToGoPatchStyle returns the path as a GoPatch style string. Paths that were
+// parsed from a string with a document reference keep it, for example
+// `#1/foo/bar`, all other paths are rendered without a document reference.
 func (path *Path) ToGoPatchStyle() string {
+	if !path.referenced {
+		return path.elementsString()
+	}
+
+	return path.documentReference() + path.elementsString()
+}
+
+// documentReference returns the GoPatch style document reference of the path,
+// which is either the document name or the document index
+func (path *Path) documentReference() string {
+	if path.DocumentName != "" {
+		return "#" + strings.Replace(path.DocumentName, "/", `\/`, -1)
+	}
+
+	return fmt.Sprintf("#%d", path.DocumentIdx)
+}
+
+// elementsString returns the GoPatch style path without document reference
+func (path *Path) elementsString() string {
 	if len(path.PathElements) == 0 {
 		return "/"
 	}
 
-	sections := []string{""}
+	return "/" + strings.Join(path.sections(), "/")
+}
+
+// sections returns the GoPatch style representation of each path element
+func (path *Path) sections() []string {
+	sections := []string{}
 	for _, element := range path.PathElements {
 		switch {
-		case element.Name != "" && element.Key == "":
+		case element.isMapElement():
 			sections = append(sections, element.Name)
 
-		case element.Name != "" && element.Key != "":
-			sections = append(sections, fmt.Sprintf("%s=%s", element.Key, element.Name))
+		case element.isComplexListElement():
+			sections = append(sections, formatIdentifierAndName(element.identifierKeys(), element.identifierNames()))
 
 		default:
 			sections = append(sections, strconv.Itoa(element.Idx))
 		}
 	}
 
-	return strings.Join(sections, "/")
+	return sections
 }
 
-// ToDotStyle returns the path as a Dot-Style string.
+// ToDotStyle returns the path as a Dot-Style string. Paths that were parsed
+// from a string with a document index reference keep it, for example
+// `1:foo.bar`, document names cannot be expressed in Dot-Style.
 func (path *Path) ToDotStyle() string {
 	sections := []string{}
 
 	for _, element := range path.PathElements {
 		switch {
+		case element.isCompositeListElement():
+			sections = append(sections, formatIdentifierAndName(element.Keys, element.Names))
+
 		case element.Name != "":
 			sections = append(sections, element.Name)
 
@@ -107,6 +162,10 @@ func (path *Path) ToDotStyle() string {
 		}
 	}
 
+	if path.referenced && path.DocumentName == "" {
+		return fmt.Sprintf("%d:%s", path.DocumentIdx, strings.Join(sections, "."))
+	}
+
 	return strings.Join(sections, ".")
 }
 
@@ -114,6 +173,10 @@ func (path *Path) ToDotStyle() string {
 // could be the number of the respective document inside a YAML or if available
 // the name of the document
 func (path *Path) RootDescription() string {
+	if path.DocumentName != "" {
+		return path.DocumentName
+	}
+
 	if path.Root != nil && path.DocumentIdx < len(path.Root.Names) {
 		return path.Root.Names[path.DocumentIdx]
 	}
@@ -131,7 +194,10 @@ func NewPathWithPathElement(path Path, pathElement PathElement) Path {
 	return Path{
 		Root:         path.Root,
 		DocumentIdx:  path.DocumentIdx,
-		PathElements: append(result, pathElement)}
+		DocumentName: path.DocumentName,
+		PathElements: append(result, pathElement),
+		referenced:   path.referenced,
+	}
 }
 
 // NewPathWithNamedElement returns a new path based on a given path adding a new
@@ -151,6 +217,16 @@ func NewPathWithNamedListElement(path Path, identifier interface{}, name interfa
 		Name: fmt.Sprintf("%v", name)})
 }
 
+// NewPathWithCompositeListElement returns a new path based on a given path
+// adding a new of type entry in a named-entry list that is identified by a
+// composite identifier using keys and names.
+func NewPathWithCompositeListElement(path Path, keys []string, names []string) Path {
+	return NewPathWithPathElement(path, PathElement{
+		Idx:   -1,
+		Keys:  keys,
+		Names: names})
+}
+
 // NewPathWithIndexedListElement returns a new path based on a given path adding
 // a new of type list entry using the index.
 func NewPathWithIndexedListElement(path Path, idx int) Path {
@@ -159,7 +235,10 @@ func NewPathWithIndexedListElement(path Path, idx int) Path {
 	})
 }
 
-// ComparePathsByValue returns all Path structure that have the same path value
+// ComparePathsByValue returns all Path structure that have the same path value,
+// where values are compared semantically (see `NodesEqual`). Paths refer to
+// the documents by name if they have a document name, or by index otherwise
+// (see `ComparePathsByValueInFiles` for custom document namers).
 func ComparePathsByValue(fromLocation string, toLocation string, duplicatePaths []Path) ([]Path, error) {
 	from, err := LoadFile(fromLocation)
 	if err != nil {
@@ -171,59 +250,30 @@ func ComparePathsByValue(fromLocation string, toLocation string, duplicatePaths
 		return nil, err
 	}
 
-	if len(from.Documents) > 1 || len(to.Documents) > 1 {
-		return nil, fmt.Errorf("input files have more than one document, which is not supported yet")
-	}
-
-	duplicatePathsWithTheSameValue := []Path{}
-
-	for _, path := range duplicatePaths {
-		fromValue, err := Grab(from.Documents[0], path.ToGoPatchStyle())
-		if err != nil {
-			return nil, err
-		}
-
-		toValue, err := Grab(to.Documents[0], path.ToGoPatchStyle())
-		if err != nil {
-			return nil, err
-		}
-
-		if reflect.DeepEqual(fromValue, toValue) {
-			duplicatePathsWithTheSameValue = append(duplicatePathsWithTheSameValue, path)
-		}
-	}
-	return duplicatePathsWithTheSameValue, nil
+	return ComparePathsByValueInFiles(from, to, duplicatePaths, CompareOptions{})
 }
 
 // ComparePaths returns all duplicate Path structures between two documents.
+// Files with multiple documents are compared document by document, where
+// documents are paired by index (see `ComparePathsByDocument` for pairing
+// documents by name).
 func ComparePaths(fromLocation string, toLocation string, compareByValue bool) ([]Path, error) {
-	var duplicatePaths []Path
-
-	pathsFromLocation, err := ListPaths(fromLocation)
+	from, err := LoadFile(fromLocation)
 	if err != nil {
 		return nil, err
 	}
-	pathsToLocation, err := ListPaths(toLocation)
+
+	to, err := LoadFile(toLocation)
 	if err != nil {
 		return nil, err
 	}
 
-	lookup := map[string]struct{}{}
-	for _, pathsFrom := range pathsFromLocation {
-		lookup[pathsFrom.ToGoPatchStyle()] = struct{}{}
-	}
-
-	for _, pathsTo := range pathsToLocation {
-		if _, ok := lookup[pathsTo.ToGoPatchStyle()]; ok {
-			duplicatePaths = append(duplicatePaths, pathsTo)
-		}
+	duplicatePaths := []Path{}
+	for _, pair := range ComparePathsByDocument(from, to, CompareOptions{CompareByValue: compareByValue}) {
+		duplicatePaths = append(duplicatePaths, pair.Paths...)
 	}
 
-	if !compareByValue {
-		return duplicatePaths, nil
-	}
-
-	return ComparePathsByValue(fromLocation, toLocation, duplicatePaths)
+	return duplicatePaths, nil
 }
 
 // ListPaths returns all paths in the documents using the provided choice of
@@ -236,11 +286,7 @@ func ListPaths(location string) ([]Path, error) {
 
 	paths := []Path{}
 	for idx, document := range inputfile.Documents {
-		root := Path{DocumentIdx: idx}
-
-		traverseTree(root, nil, document, func(path Path, _ *yamlv3.Node, _ *yamlv3.Node) {
-			paths = append(paths, path)
-		})
+		paths = append(paths, documentPaths(Path{DocumentIdx: idx}, document)...)
 	}
 
 	return paths, nil
@@ -258,7 +304,7 @@ func IsPathInTree(tree *yamlv3.Node, pathString string) (bool, error) {
 	go func() {
 		for _, node := range tree.Content {
 			traverseTree(Path{}, nil, node, func(path Path, _ *yamlv3.Node, _ *yamlv3.Node) {
-				if path.ToGoPatchStyle() == searchPath.ToGoPatchStyle() {
+				if path.elementsString() == searchPath.elementsString() {
 					resultChan <- true
 				}
 			})
@@ -281,13 +327,12 @@ func traverseTree(path Path, parent *yamlv3.Node, node *yamlv3.Node, leafFunc fu
 		)
 
 	case yamlv3.SequenceNode:
-		if identifier := GetIdentifierFromNamedList(node); identifier != "" {
+		if keys := DefaultIdentifierResolver.Identifier(path, node); keys != nil {
 			for _, mappingNode := range node.Content {
-				name, _ := getValueByKey(mappingNode, identifier)
-				tmpPath := NewPathWithNamedListElement(path, identifier, name.Value)
+				tmpPath := NewPathWithPathElement(path, namedListEntryElement(mappingNode, keys))
 				for i := 0; i < len(mappingNode.Content); i += 2 {
 					k, v := mappingNode.Content[i], mappingNode.Content[i+1]
-					if k.Value == identifier { // skip the identifier mapping entry
+					if isIdentifierKey(keys, k.Value) { // skip the identifier mapping entries
 						continue
 					}
 
@@ -328,7 +373,10 @@ func traverseTree(path Path, parent *yamlv3.Node, node *yamlv3.Node, leafFunc fu
 }
 
 // ParseGoPatchStylePathString returns a path by parsing a string representation
-// which is assumed to be a GoPatch style path.
+// which is assumed to be a GoPatch style path. The path can optionally start
+// with a reference to a document, either using the document index (starting
+// with 0) like in `#2/spec/replicas`, or the document name like in
+// `#web/spec/replicas`. Slashes in document names need to be escaped.
 func ParseGoPatchStylePathString(path string) (Path, error) {
 	// Special case for root path
 	if path == "/" {
@@ -339,9 +387,28 @@ func ParseGoPatchStylePathString(path string) (Path, error) {
 	// replacement string that is later resolved into a simple slash
 	path = strings.Replace(path, `\/`, `%2F`, -1)
 
+	sections := strings.Split(path, "/")
+	documentIdx, documentName, err := parseGoPatchDocumentReference(path, sections[0])
+	if err != nil {
+		return Path{}, err
+	}
+
+	referenced := sections[0] != ""
+
+	// Special case for root path of a referenced document
+	if len(sections) == 1 || (len(sections) == 2 && sections[1] == "") {
+		return Path{DocumentIdx: documentIdx, DocumentName: documentName, PathElements: nil, referenced: referenced}, nil
+	}
+
 	elements := make([]PathElement, 0)
-	for i, section := range strings.Split(path, "/") {
-		if i == 0 {
+	for _, section := range sections[1:] {
+		if keys, names, ok := parseCompositeIdentifier(section); ok {
+			for i := range keys {
+				keys[i] = strings.Replace(keys[i], `%2F`, "/", -1)
+				names[i] = strings.Replace(names[i], `%2F`, "/", -1)
+			}
+
+			elements = append(elements, PathElement{Idx: -1, Keys: keys, Names: names})
 			continue
 		}
 
@@ -375,7 +442,127 @@ func ParseGoPatchStylePathString(path string) (Path, error) {
 		}
 	}
 
-	return Path{DocumentIdx: 0, PathElements: elements}, nil
+	return Path{DocumentIdx: documentIdx, DocumentName: documentName, PathElements: elements, referenced: referenced}, nil
+}
+
+// parseCompositeIdentifier parses a named-entry list element with a composite
+// identifier like `containerPort=80,protocol=TCP` into the list of keys and
+// the list of names
+func parseCompositeIdentifier(section string) ([]string, []string, bool) {
+	pairs := strings.Split(section, ",")
+	if len(pairs) < 2 {
+		return nil, nil, false
+	}
+
+	keys, names := make([]string, len(pairs)), make([]string, len(pairs))
+	for i, pair := range pairs {
+		keyNameSplit := strings.Split(pair, "=")
+		if len(keyNameSplit) != 2 || keyNameSplit[0] == "" {
+			return nil, nil, false
+		}
+
+		keys[i], names[i] = keyNameSplit[0], keyNameSplit[1]
+	}
+
+	return keys, names, true
+}
+
+// parseExplicitIdentifier parses a named-entry list element that explicitly
+// names its identifier, either as `key=name` or as composite identifier
+func parseExplicitIdentifier(section string) ([]string, []string, bool) {
+	if keys, names, ok := parseCompositeIdentifier(section); ok {
+		return keys, names, true
+	}
+
+	if keyNameSplit := strings.SplitN(section, "=", 2); len(keyNameSplit) == 2 && keyNameSplit[0] != "" {
+		return keyNameSplit[:1], keyNameSplit[1:], true
+	}
+
+	return nil, nil, false
+}
+
+// parseGoPatchDocumentReference parses the optional document reference in
+// front of the first slash of a GoPatch style path, which is either a document
+// index or a document name
+func parseGoPatchDocumentReference(path string, reference string) (int, string, error) {
+	if !strings.HasPrefix(reference, "#") {
+		return 0, "", nil
+	}
+
+	reference = strings.TrimPrefix(reference, "#")
+	if reference == "" {
+		return 0, "", NewInvalidPathError(GoPatchStyle, path,
+			"document reference cannot be empty",
+		)
+	}
+
+	if idx, err := strconv.Atoi(reference); err == nil {
+		if idx < 0 {
+			return 0, "", NewInvalidPathError(GoPatchStyle, path,
+				"document index %d cannot be negative", idx,
+			)
+		}
+
+		return idx, "", nil
+	}
+
+	return 0, strings.Replace(reference, `%2F`, "/", -1), nil
+}
+
+// resolveDocumentPath parses the provided path string and returns the document
+// of the input file it refers to together with the parsed path. The document
+// can be referenced by index or name in GoPatch style paths (`#2/foo/bar`,
+// `#name/foo/bar`) and by index in Dot-Style paths (`2:foo.bar`).
+func resolveDocumentPath(inputFile InputFile, pathString string) (*yamlv3.Node, Path, error) {
+	path, err := ParsePathStringUnsafe(pathString)
+	if err != nil {
+		return nil, Path{}, err
+	}
+
+	documentIdx := path.DocumentIdx
+	if path.DocumentName != "" {
+		documentIdx = -1
+		for idx, name := range inputFile.Names {
+			if name == path.DocumentName && idx < len(inputFile.Documents) {
+				documentIdx = idx
+				break
+			}
+		}
+
+		if documentIdx < 0 {
+			return nil, Path{}, NewInvalidPathError(GoPatchStyle, pathString,
+				"there is no document named '%s', available names are: %s",
+				path.DocumentName,
+				strings.Join(inputFile.Names, ", "),
+			)
+		}
+	}
+
+	if documentIdx >= len(inputFile.Documents) {
+		return nil, Path{}, NewInvalidPathError(styleOf(pathString), pathString,
+			"provided document index %d is not in range: 0..%d",
+			documentIdx,
+			len(inputFile.Documents)-1,
+		)
+	}
+
+	document := inputFile.Documents[documentIdx]
+
+	// Dot-Style paths can only be parsed properly by checking the path
+	// elements against the actual document
+	if styleOf(pathString) == DotStyle {
+		matches := dotRegEx.FindStringSubmatch(pathString)
+		if path, err = ParseDotStylePathString(matches[3], document); err != nil {
+			return nil, Path{}, err
+		}
+
+		path.referenced = len(matches[2]) > 0
+	}
+
+	path.Root = &inputFile
+	path.DocumentIdx = documentIdx
+
+	return document, path, nil
 }
 
 // ParseDotStylePathString returns a path by parsing a string representation
@@ -408,6 +595,8 @@ func ParseDotStylePathString(path string, node *yamlv3.Node) (Path, error) {
 
 		case pointer.Kind == yamlv3.SequenceNode:
 			list := pointer.Content
+			identifier := DefaultIdentifierResolver.Identifier(Path{PathElements: elements}, pointer)
+
 			if id, err := strconv.Atoi(section); err == nil {
 				if id < 0 || id >= len(list) {
 					return Path{}, &InvalidPathString{
@@ -419,29 +608,43 @@ func ParseDotStylePathString(path string, node *yamlv3.Node) (Path, error) {
 
 				pointer = list[id]
 				elements = append(elements, PathElement{Idx: id})
+				continue
+			}
 
-			} else {
-				identifier := GetIdentifierFromNamedList(pointer)
-				value, ok := getEntryFromNamedList(pointer, identifier, section)
-				if !ok {
-					names, err := listNamesOfNamedList(pointer, identifier)
-					if err != nil {
-						return Path{}, &InvalidPathString{
-							Style:       DotStyle,
-							PathString:  path,
-							Explanation: fmt.Sprintf("provided named list entry '%s' cannot be found in list", section),
-						}
-					}
+			// Named-entry list entries with numeric names (for example container
+			// ports) or composite identifiers have to be referenced explicitly
+			// using `key=name` or `key=name,key=name`, since sections consisting
+			// of digits only are list indexes
+			if keys, names, ok := parseExplicitIdentifier(section); ok {
+				if value, ok := getEntryFromNamedList(pointer, keys, names); ok {
+					pointer = value
+					elements = append(elements, namedListElement(keys, names))
+					continue
+				}
+			}
 
-					return Path{}, &InvalidPathString{
-						Style:       DotStyle,
-						PathString:  path,
-						Explanation: fmt.Sprintf("provided named list entry '%s' cannot be found in list, available names are: %s", section, strings.Join(names, ", ")),
-					}
+			if len(identifier) == 1 {
+				if value, ok := getEntryFromNamedList(pointer, identifier, []string{section}); ok {
+					pointer = value
+					elements = append(elements, namedListElement(identifier, []string{section}))
+					continue
 				}
+			}
 
-				pointer = value
-				elements = append(elements, PathElement{Idx: -1, Key: identifier, Name: section})
+			names, err := listNamesOfNamedList(pointer, identifier)
+			if err != nil {
+				return Path{}, &InvalidPathString{
+					Style:       DotStyle,
+					PathString:  path,
+					Explanation: fmt.Sprintf("provided named list entry '%s' cannot be found in list", section),
+				}
+			}
+
+			return Path{}, &InvalidPathString{
+				Style:       DotStyle,
+				PathString:  path,
+				Explanation: fmt.Sprintf("provided named list entry '%s' cannot be found in list, available names are: %s", section, strings.Join(names, ", ")),
+				Suggestions: suggest(section, names),
 			}
 		}
 	}
@@ -486,13 +689,13 @@ func ParseDotStylePathStringUnsafe(path string) (Path, error) {
 		}
 	}
 
-	return Path{DocumentIdx: documentIdx, PathElements: elements}, nil
+	return Path{DocumentIdx: documentIdx, PathElements: elements, referenced: len(matches[2]) > 0}, nil
 }
 
 // ParsePathString returns a path by parsing a string representation
 // of a path, which can be one of the supported types.
 func ParsePathString(pathString string, node *yamlv3.Node) (Path, error) {
-	if strings.HasPrefix(pathString, "/") {
+	if styleOf(pathString) == GoPatchStyle {
 		return ParseGoPatchStylePathString(pathString)
 	}
 
@@ -503,24 +706,74 @@ func ParsePathString(pathString string, node *yamlv3.Node) (Path, error) {
 // path, which can either be GoPatch or DotStyle, but will not check the path
 // elements against a given YAML document to verify the types (unsafe)
 func ParsePathStringUnsafe(pathString string) (Path, error) {
-	if strings.HasPrefix(pathString, "/") {
+	if styleOf(pathString) == GoPatchStyle {
 		return ParseGoPatchStylePathString(pathString)
 	}
 
 	return ParseDotStylePathStringUnsafe(pathString)
 }
 
+// styleOf returns the path style of the provided path string, which is GoPatch
+// style for paths starting with a slash or a document reference followed by a
+// slash, like `#1/foo` or `#name/foo`
+func styleOf(pathString string) PathStyle {
+	if strings.HasPrefix(pathString, "/") || documentReferenceRegEx.MatchString(pathString) {
+		return GoPatchStyle
+	}
+
+	return DotStyle
+}
+
+// rejectDocumentReference returns an error if the path refers to a document
+// other than the provided one, which can only be resolved using an input file
+func rejectDocumentReference(pathString string, path Path) error {
+	if path.DocumentName != "" || path.DocumentIdx > 0 {
+		return NewInvalidPathError(styleOf(pathString), pathString,
+			"document reference %s cannot be resolved in a single document, use an input file instead",
+			path.documentReference(),
+		)
+	}
+
+	return nil
+}
+
 func (element PathElement) isMapElement() bool {
 	return len(element.Key) == 0 &&
-		len(element.Name) > 0
+		len(element.Name) > 0 &&
+		!element.isCompositeListElement()
 }
 
 func (element PathElement) isComplexListElement() bool {
-	return len(element.Key) > 0 &&
-		len(element.Name) > 0
+	return (len(element.Key) > 0 && len(element.Name) > 0) ||
+		element.isCompositeListElement()
+}
+
+func (element PathElement) isCompositeListElement() bool {
+	return len(element.Keys) > 0
 }
 
 func (element PathElement) isSimpleListElement() bool {
 	return len(element.Key) == 0 &&
-		len(element.Name) == 0
+		len(element.Name) == 0 &&
+		!element.isCompositeListElement()
+}
+
+// identifierKeys returns the identifier keys of a named-entry list element,
+// which is more than one in case of a composite identifier
+func (element PathElement) identifierKeys() []string {
+	if element.isCompositeListElement() {
+		return element.Keys
+	}
+
+	return []string{element.Key}
+}
+
+// identifierNames returns the names of a named-entry list element, which is
+// more than one in case of a composite identifier
+func (element PathElement) identifierNames() []string {
+	if element.isCompositeListElement() {
+		return element.Names
+	}
+
+	return []string{element.Name}
 }
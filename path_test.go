@@ -21,6 +21,8 @@
 package ytbx_test
 
 import (
+	"fmt"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -189,6 +191,113 @@ var _ = Describe("path tests", func() {
 		})
 	})
 
+	Context("parse go-patch style path strings with composite identifiers", func() {
+		It("should parse named-entry list elements with multiple keys", func() {
+			path, err := ParseGoPatchStylePathString("/ports/containerPort=80,protocol=TCP/name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(BeEquivalentTo(Path{DocumentIdx: 0, PathElements: []PathElement{
+				{Idx: -1, Key: "", Name: "ports"},
+				{Idx: -1, Keys: []string{"containerPort", "protocol"}, Names: []string{"80", "TCP"}},
+				{Idx: -1, Key: "", Name: "name"},
+			}}))
+			Expect(path.ToGoPatchStyle()).To(Equal("/ports/containerPort=80,protocol=TCP/name"))
+		})
+
+		It("should still support commas in names of single key elements", func() {
+			path, err := ParseGoPatchStylePathString("/list/name=a,b")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(BeEquivalentTo(Path{DocumentIdx: 0, PathElements: []PathElement{
+				{Idx: -1, Key: "", Name: "list"},
+				{Idx: -1, Key: "name", Name: "a,b"},
+			}}))
+			Expect(path.ToGoPatchStyle()).To(Equal("/list/name=a,b"))
+		})
+	})
+
+	Context("parse go-patch style path strings with document references", func() {
+		It("should parse a document index reference", func() {
+			path, err := ParseGoPatchStylePathString("#2/spec/replicas")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path.DocumentIdx).To(Equal(2))
+			Expect(path.PathElements).To(Equal([]PathElement{
+				{Idx: -1, Key: "", Name: "spec"},
+				{Idx: -1, Key: "", Name: "replicas"},
+			}))
+		})
+
+		It("should parse a document name reference with escaped slashes", func() {
+			path, err := ParseGoPatchStylePathString("#Deployment\\/default\\/web/spec/replicas")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path.DocumentName).To(Equal("Deployment/default/web"))
+			Expect(path.PathElements).To(Equal([]PathElement{
+				{Idx: -1, Key: "", Name: "spec"},
+				{Idx: -1, Key: "", Name: "replicas"},
+			}))
+			Expect(path.RootDescription()).To(Equal("Deployment/default/web"))
+		})
+
+		It("should parse a reference to the root of a document", func() {
+			for _, pathString := range []string{"#1", "#1/"} {
+				path, err := ParseGoPatchStylePathString(pathString)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(path.DocumentIdx).To(Equal(1))
+				Expect(path.PathElements).To(BeEmpty())
+			}
+		})
+
+		It("should fail for invalid document references", func() {
+			_, err := ParseGoPatchStylePathString("#/spec")
+			Expect(err).To(HaveOccurred())
+
+			_, err = ParseGoPatchStylePathString("#-1/spec")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should detect document references as go-patch style paths", func() {
+			path, err := ParsePathStringUnsafe("#1/spec")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path.DocumentIdx).To(Equal(1))
+			Expect(path.PathElements).To(Equal([]PathElement{
+				{Idx: -1, Key: "", Name: "spec"},
+			}))
+		})
+
+		It("should not treat keys starting with a hash as document references", func() {
+			Expect(ParsePathStringUnsafe("#foo")).To(BeEquivalentTo(Path{PathElements: []PathElement{
+				{Idx: -1, Key: "", Name: "#foo"},
+			}}))
+		})
+
+		It("should turn parsed paths back into the same string", func() {
+			for _, pathString := range []string{"/spec/replicas", "#2/spec/replicas", "#Deployment\\/default\\/web/spec/replicas", "#1/"} {
+				path, err := ParseGoPatchStylePathString(pathString)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(path.ToGoPatchStyle()).To(Equal(pathString))
+			}
+
+			path, err := ParsePathStringUnsafe("1:spec.replicas")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path.ToDotStyle()).To(Equal("1:spec.replicas"))
+		})
+
+		It("should render paths of other documents without document reference", func() {
+			input, err := LoadFile(assets("testbed", "multi_a.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			paths, err := ListPaths(assets("testbed", "multi_a.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			path := paths[len(paths)-1]
+			Expect(path.DocumentIdx).To(Equal(1))
+			Expect(path.ToGoPatchStyle()).To(Equal("/spec/type"))
+			Expect(path.ToDotStyle()).To(Equal("spec.type"))
+
+			node, err := Grab(input.Documents[1], path.ToGoPatchStyle())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node.Value).To(Equal("ClusterIP"))
+		})
+	})
+
 	Context("compare paths between two files", func() {
 		It("should find only duplicate paths", func() {
 			list, err := ComparePaths(assets("testbed", "sample_a.yml"), assets("testbed", "sample_b.yml"), false)
@@ -221,6 +330,135 @@ var _ = Describe("path tests", func() {
 			Expect(list).To(BeEquivalentTo(listOfPaths))
 		})
 
+		It("should find paths with the same value in different places and styles", func() {
+			list, err := ComparePaths(assets("testbed", "sample_a.yml"), assets("testbed", "sample_c.yml"), true)
+			Expect(err).ToNot(HaveOccurred())
+
+			var paths []string
+			for _, path := range list {
+				paths = append(paths, path.ToGoPatchStyle())
+			}
+
+			Expect(paths).To(ConsistOf(
+				"/yaml/structure/somekey",
+				"/yaml/structure/dot",
+				"/list/name=one/somekey",
+				"/list/name=sametwo/somekey",
+			))
+		})
+
+		It("should compare files with multiple documents document by document", func() {
+			list, err := ComparePaths(assets("testbed", "multi_a.yml"), assets("testbed", "multi_b.yml"), true)
+			Expect(err).ToNot(HaveOccurred())
+
+			var paths []string
+			for _, path := range list {
+				paths = append(paths, fmt.Sprintf("#%d%s", path.DocumentIdx, path.ToGoPatchStyle()))
+			}
+
+			Expect(paths).To(ConsistOf(
+				"#0/apiVersion",
+				"#1/apiVersion",
+			))
+		})
+
+		It("should pair documents by name", func() {
+			from, err := LoadFile(assets("testbed", "multi_a.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			to, err := LoadFile(assets("testbed", "multi_b.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			pairs := ComparePathsByDocument(from, to, CompareOptions{Pairing: PairByName, CompareByValue: true})
+			Expect(pairs).To(HaveLen(2))
+
+			Expect(pairs[0].Name).To(Equal("Service/web"))
+			Expect(pairs[0].FromIdx).To(Equal(1))
+			Expect(pairs[0].ToIdx).To(Equal(0))
+			Expect(pairs[0].Paths).To(HaveLen(3))
+			Expect(pairs[0].Paths[0].DocumentName).To(Equal("Service/web"))
+
+			Expect(pairs[1].Name).To(Equal("ConfigMap/config"))
+			var paths []string
+			for _, path := range pairs[1].Paths {
+				paths = append(paths, path.ToGoPatchStyle())
+			}
+
+			Expect(paths).To(Equal([]string{"/apiVersion", "/kind", "/metadata/name", "/data/mode"}))
+
+			duplicates, err := ComparePathsByValue(assets("testbed", "multi_a.yml"), assets("testbed", "multi_b.yml"), pairs[1].Paths)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(duplicates).To(Equal(pairs[1].Paths))
+		})
+
+		It("should compare values in documents named by custom namers", func() {
+			from, err := LoadFile(assets("testbed", "multi_a.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			to, err := LoadFile(assets("testbed", "multi_b.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			namer := func(document *yamlv3.Node) (string, bool) {
+				name, err := GrabString(document, "/metadata/name")
+				return name, err == nil
+			}
+
+			opts := CompareOptions{Pairing: PairByName, Namers: []DocumentNamer{namer}}
+			pairs := ComparePathsByDocument(from, to, opts)
+			Expect(pairs).To(HaveLen(2))
+			Expect(pairs[1].Name).To(Equal("config"))
+
+			duplicates, err := ComparePathsByValueInFiles(from, to, pairs[1].Paths, opts)
+			Expect(err).ToNot(HaveOccurred())
+
+			var paths []string
+			for _, path := range duplicates {
+				paths = append(paths, path.ToGoPatchStyle())
+			}
+
+			Expect(paths).To(Equal([]string{"/apiVersion", "/kind", "/metadata/name", "/data/mode"}))
+		})
+
+		It("should compare values in the paired documents if names repeat", func() {
+			from, to := inputFile(`{name: a, x: 1}`, `{name: a, x: 2}`), inputFile(`{name: a, x: 3}`, `{name: a, x: 2}`)
+
+			namer := func(document *yamlv3.Node) (string, bool) {
+				name, err := GrabString(document, "/name")
+				return name, err == nil
+			}
+
+			opts := CompareOptions{Pairing: PairByName, Namers: []DocumentNamer{namer}}
+			pairs := ComparePathsByDocument(from, to, opts)
+			Expect(pairs).To(HaveLen(2))
+
+			var paths [][]string
+			for _, pair := range pairs {
+				duplicates, err := ComparePathsByValueInFiles(from, to, pair.Paths, opts)
+				Expect(err).ToNot(HaveOccurred())
+
+				var list []string
+				for _, path := range duplicates {
+					list = append(list, path.ToGoPatchStyle())
+				}
+
+				paths = append(paths, list)
+			}
+
+			Expect(paths).To(Equal([][]string{{"/name"}, {"/name", "/x"}}))
+		})
+
+		It("should refer to missing documents by their zero-based index", func() {
+			_, err := ComparePathsByValueInFiles(inputFile(`{a: 1}`), inputFile(`{a: 1}`), []Path{{DocumentIdx: 1}}, CompareOptions{})
+			Expect(err).To(MatchError(ContainSubstring("there is no document #1 in")))
+		})
+
+		It("should return an empty list if there are no duplicate paths", func() {
+			list, err := ComparePaths(assets("examples", "empty.yml"), assets("testbed", "sample_a.yml"), false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(list).ToNot(BeNil())
+			Expect(list).To(BeEmpty())
+		})
+
 		It("should find only paths with the same value", func() {
 			list, err := ComparePaths(assets("testbed", "sample_a.yml"), assets("testbed", "sample_b.yml"), true)
 			Expect(err).ToNot(HaveOccurred())
@@ -0,0 +1,240 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"fmt"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// PathSetOptions controls how paths of a `PathSet` are identified
+type PathSetOptions struct {
+	// Pairing defines how documents are identified across files, either by
+	// their index, or by their name as returned by the document namers
+	Pairing DocumentPairing
+
+	// Namers are used to name documents when identifying them by name, the
+	// default document namers are used if none are provided
+	Namers []DocumentNamer
+
+	// CompareByValue considers two paths only equal if they also have the
+	// same value, where values are compared using their canonical form (see
+	// `Canonicalize`), otherwise only the paths themselves are compared
+	CompareByValue bool
+}
+
+// PathSet is a set of paths, for example all paths of a file, that supports
+// set operations with the path sets of other files. All path sets used in one
+// operation should be created using the same options. Set operations keep the
+// order in which paths were added.
+type PathSet struct {
+	opts     PathSetOptions
+	keys     []string
+	entries  map[string]Path
+	pathKeys map[string]struct{}
+}
+
+// NewPathSet returns a path set of the provided paths, for example the result
+// of `ListPaths`. Comparing paths by value requires paths that refer to their
+// input file (see `Path.Root`), paths with a value that cannot be resolved are
+// not part of the set in this case, since their value is unknown.
+func NewPathSet(opts PathSetOptions, paths ...Path) PathSet {
+	set := newPathSet(opts)
+	for _, path := range paths {
+		var value string
+		if opts.CompareByValue {
+			node, ok := valueOfPath(path)
+			if !ok {
+				continue
+			}
+
+			value = Hash(node)
+		}
+
+		set.add(path, value)
+	}
+
+	return set
+}
+
+// valueOfPath returns the value the path refers to in its input file
+func valueOfPath(path Path) (*yamlv3.Node, bool) {
+	if path.Root == nil || path.DocumentIdx < 0 || path.DocumentIdx >= len(path.Root.Documents) {
+		return nil, false
+	}
+
+	node, err := grabByPath(documentRoot(path.Root.Documents[path.DocumentIdx]), path)
+	if err != nil {
+		return nil, false
+	}
+
+	return node, true
+}
+
+// NewPathSetFromInputFile returns a path set of all paths of all documents of
+// the input file
+func NewPathSetFromInputFile(opts PathSetOptions, inputFile InputFile) PathSet {
+	var names []string
+	if opts.Pairing == PairByName {
+		names = NameDocuments(inputFile, opts.Namers...).Names
+	}
+
+	set := newPathSet(opts)
+	for idx, document := range inputFile.Documents {
+		root := Path{Root: &inputFile, DocumentIdx: idx}
+		if names != nil {
+			root.DocumentName = names[idx]
+		}
+
+		traverseTree(root, nil, document, func(path Path, _ *yamlv3.Node, leaf *yamlv3.Node) {
+			var value string
+			if opts.CompareByValue {
+				value = Hash(leaf)
+			}
+
+			set.add(path, value)
+		})
+	}
+
+	return set
+}
+
+// LoadPathSet returns a path set of all paths of all documents of the file at
+// the provided location
+func LoadPathSet(opts PathSetOptions, location string) (PathSet, error) {
+	inputFile, err := LoadFile(location)
+	if err != nil {
+		return PathSet{}, err
+	}
+
+	return NewPathSetFromInputFile(opts, inputFile), nil
+}
+
+func newPathSet(opts PathSetOptions) PathSet {
+	return PathSet{opts: opts, entries: map[string]Path{}, pathKeys: map[string]struct{}{}}
+}
+
+// Len returns the number of paths in the set
+func (set PathSet) Len() int {
+	return len(set.keys)
+}
+
+// Paths returns the paths of the set in the order they were added
+func (set PathSet) Paths() []Path {
+	paths := make([]Path, 0, len(set.keys))
+	for _, key := range set.keys {
+		paths = append(paths, set.entries[key])
+	}
+
+	return paths
+}
+
+// Contains returns whether the set contains the provided path, regardless of
+// its value
+func (set PathSet) Contains(path Path) bool {
+	_, ok := set.pathKeys[set.pathKey(path)]
+	return ok
+}
+
+// Union returns a set with all paths that are in this set or any of the
+// other sets
+func (set PathSet) Union(others ...PathSet) PathSet {
+	return set.combine(others, func(count int, _ bool) bool {
+		return count > 0
+	})
+}
+
+// Intersection returns a set with all paths that are in this set and in all
+// of the other sets
+func (set PathSet) Intersection(others ...PathSet) PathSet {
+	return set.combine(others, func(count int, _ bool) bool {
+		return count == len(others)+1
+	})
+}
+
+// Difference returns a set with all paths of this set that are in none of
+// the other sets
+func (set PathSet) Difference(others ...PathSet) PathSet {
+	return set.combine(others, func(count int, inSet bool) bool {
+		return inSet && count == 1
+	})
+}
+
+// SymmetricDifference returns a set with all paths that are in exactly one of
+// the sets, which is this set or any of the other sets
+func (set PathSet) SymmetricDifference(others ...PathSet) PathSet {
+	return set.combine(others, func(count int, _ bool) bool {
+		return count == 1
+	})
+}
+
+// combine returns a set with the paths of all sets that satisfy the provided
+// function, which gets the number of sets containing the path and whether this
+// set contains it
+func (set PathSet) combine(others []PathSet, include func(count int, inSet bool) bool) PathSet {
+	counts := map[string]int{}
+	for _, other := range append([]PathSet{set}, others...) {
+		for _, key := range other.keys {
+			counts[key]++
+		}
+	}
+
+	result := newPathSet(set.opts)
+	for _, other := range append([]PathSet{set}, others...) {
+		for _, key := range other.keys {
+			if _, ok := set.entries[key]; include(counts[key], ok) {
+				result.insert(key, other.entries[key])
+			}
+		}
+	}
+
+	return result
+}
+
+func (set *PathSet) add(path Path, value string) {
+	key := set.pathKey(path)
+	if set.opts.CompareByValue {
+		key = fmt.Sprintf("%s=%s", key, value)
+	}
+
+	set.insert(key, path)
+}
+
+func (set *PathSet) insert(key string, path Path) {
+	if _, ok := set.entries[key]; ok {
+		return
+	}
+
+	set.keys = append(set.keys, key)
+	set.entries[key] = path
+	set.pathKeys[set.pathKey(path)] = struct{}{}
+}
+
+// pathKey returns a representation of the path including the document, which
+// is identified by name or by index depending on the pairing
+func (set PathSet) pathKey(path Path) string {
+	if set.opts.Pairing == PairByName && path.DocumentName != "" {
+		return fmt.Sprintf("#%s%s", path.DocumentName, path.ToGoPatchStyle())
+	}
+
+	return fmt.Sprintf("#%d%s", path.DocumentIdx, path.ToGoPatchStyle())
+}
@@ -0,0 +1,157 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+)
+
+var _ = Describe("Path sets", func() {
+	pathStrings := func(set PathSet) []string {
+		result := []string{}
+		for _, path := range set.Paths() {
+			result = append(result, path.String())
+		}
+
+		return result
+	}
+
+	var dev, staging, prod InputFile
+
+	BeforeEach(func() {
+		dev = inputFile(`{replicas: 1, debug: true, image: {tag: dev}}`)
+		staging = inputFile(`{replicas: 2, image: {tag: latest}, region: eu}`)
+		prod = inputFile(`{replicas: 5, image: {tag: latest}, region: us, alerts: pager}`)
+	})
+
+	Context("Comparing paths", func() {
+		var opts PathSetOptions
+
+		It("should list all paths of an input file", func() {
+			set := NewPathSetFromInputFile(opts, dev)
+			Expect(set.Len()).To(Equal(3))
+			Expect(pathStrings(set)).To(Equal([]string{"/replicas", "/debug", "/image/tag"}))
+		})
+
+		It("should support paths returned by ListPaths", func() {
+			paths, err := ListPaths(assets("testbed", "sample_a.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			set := NewPathSet(opts, paths...)
+			Expect(set.Len()).To(Equal(len(paths)))
+
+			loaded, err := LoadPathSet(opts, assets("testbed", "sample_a.yml"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pathStrings(set.SymmetricDifference(loaded))).To(BeEmpty())
+		})
+
+		It("should return the union of multiple sets", func() {
+			set := NewPathSetFromInputFile(opts, dev).Union(NewPathSetFromInputFile(opts, staging), NewPathSetFromInputFile(opts, prod))
+			Expect(pathStrings(set)).To(Equal([]string{"/replicas", "/debug", "/image/tag", "/region", "/alerts"}))
+		})
+
+		It("should return the intersection of multiple sets", func() {
+			set := NewPathSetFromInputFile(opts, dev).Intersection(NewPathSetFromInputFile(opts, staging), NewPathSetFromInputFile(opts, prod))
+			Expect(pathStrings(set)).To(Equal([]string{"/replicas", "/image/tag"}))
+		})
+
+		It("should return the difference to multiple sets", func() {
+			set := NewPathSetFromInputFile(opts, prod).Difference(NewPathSetFromInputFile(opts, dev), NewPathSetFromInputFile(opts, staging))
+			Expect(pathStrings(set)).To(Equal([]string{"/alerts"}))
+		})
+
+		It("should return the paths that are in exactly one of the sets", func() {
+			set := NewPathSetFromInputFile(opts, dev).SymmetricDifference(NewPathSetFromInputFile(opts, staging), NewPathSetFromInputFile(opts, prod))
+			Expect(pathStrings(set)).To(Equal([]string{"/debug", "/alerts"}))
+		})
+
+		It("should check whether a path is in the set", func() {
+			path, err := ParseGoPatchStylePathString("/image/tag")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(NewPathSetFromInputFile(opts, dev).Contains(path)).To(BeTrue())
+
+			path, err = ParseGoPatchStylePathString("/region")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(NewPathSetFromInputFile(opts, dev).Contains(path)).To(BeFalse())
+		})
+	})
+
+	Context("Comparing paths by value", func() {
+		opts := PathSetOptions{CompareByValue: true}
+
+		It("should only consider paths with the same value equal", func() {
+			set := NewPathSetFromInputFile(opts, staging).Intersection(NewPathSetFromInputFile(opts, prod))
+			Expect(pathStrings(set)).To(Equal([]string{"/image/tag"}))
+		})
+
+		It("should keep paths with different values in a union", func() {
+			set := NewPathSetFromInputFile(opts, staging).Union(NewPathSetFromInputFile(opts, prod))
+			Expect(pathStrings(set)).To(Equal([]string{"/replicas", "/image/tag", "/region", "/replicas", "/region", "/alerts"}))
+		})
+
+		It("should compare values semantically", func() {
+			a := NewPathSetFromInputFile(opts, inputFile(`{enabled: yes, "port": 0x50}`))
+			b := NewPathSetFromInputFile(opts, inputFile(`{port: 80, enabled: true}`))
+			Expect(a.Difference(b).Len()).To(Equal(0))
+		})
+
+		It("should use the values of paths that refer to their input file", func() {
+			paths := NewPathSetFromInputFile(PathSetOptions{}, staging).Paths()
+			set := NewPathSet(opts, paths...).Intersection(NewPathSetFromInputFile(opts, prod))
+			Expect(pathStrings(set)).To(Equal([]string{"/image/tag"}))
+		})
+
+		It("should leave out paths with values that cannot be resolved", func() {
+			path, err := ParseGoPatchStylePathString("/replicas")
+			Expect(err).ToNot(HaveOccurred())
+
+			missing := NewPathSetFromInputFile(PathSetOptions{}, staging).Paths()[0]
+			missing.Root = &dev
+			missing.PathElements = append(missing.PathElements, PathElement{Idx: -1, Name: "missing"})
+
+			set := NewPathSet(opts, path, missing)
+			Expect(set.Len()).To(Equal(0))
+			Expect(set.Contains(path)).To(BeFalse())
+		})
+	})
+
+	Context("Comparing files with multiple documents", func() {
+		It("should identify documents by name if configured", func() {
+			from, err := LoadFile(assets("testbed", "multi_a.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			to, err := LoadFile(assets("testbed", "multi_b.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			byIndex := PathSetOptions{CompareByValue: true}
+			Expect(pathStrings(NewPathSetFromInputFile(byIndex, from).Intersection(NewPathSetFromInputFile(byIndex, to)))).To(Equal([]string{"/apiVersion", "/apiVersion"}))
+
+			byName := PathSetOptions{CompareByValue: true, Pairing: PairByName}
+			set := NewPathSetFromInputFile(byName, from).Intersection(NewPathSetFromInputFile(byName, to))
+			Expect(pathStrings(set)).To(ContainElement("/data/mode"))
+			Expect(pathStrings(set)).ToNot(ContainElement("/data/level"))
+			Expect(pathStrings(set)).ToNot(ContainElement("/spec/type"))
+		})
+	})
+})
@@ -21,15 +21,122 @@
 package ytbx
 
 import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 
 	yamlv3 "go.yaml.in/yaml/v3"
 )
 
 // DisableRemainingKeySort disables that during restructuring of map keys, all
 // unknown keys are also sorted in such a way that it improves the readability.
+//
+// Deprecated: Use a `Restructurer` with `RemainingKeySort` set to `SortNone`,
+// which unlike this global setting is safe for concurrent use.
 var DisableRemainingKeySort = false
 
+// SortStrategy defines how keys of a map that are not part of the key order
+// rule used for that map are sorted during restructuring
+type SortStrategy int
+
+// Supported strategies are to sort keys by the depth of their values, which
+// moves long and possibly hard to read structures to the end of the map, to
+// sort keys alphabetically, or to keep the keys in their original order
+const (
+	SortByDepth SortStrategy = iota
+	SortAlphabetically
+	SortNone
+)
+
+func (strategy SortStrategy) String() string {
+	switch strategy {
+	case SortByDepth:
+		return "depth"
+
+	case SortAlphabetically:
+		return "alphabetical"
+
+	case SortNone:
+		return "none"
+
+	default:
+		return "unknown"
+	}
+}
+
+// ListSortStrategy defines how lists of scalar values are sorted during
+// restructuring
+type ListSortStrategy int
+
+// Supported strategies are to keep the original order of the list, to sort
+// the values alphabetically, or to sort them numerically, where numbers are
+// sorted by value and come before all other values, which are sorted
+// alphabetically
+const (
+	ListSortNone ListSortStrategy = iota
+	ListSortAlphabetically
+	ListSortNumerically
+)
+
+func (strategy ListSortStrategy) String() string {
+	switch strategy {
+	case ListSortNone:
+		return "none"
+
+	case ListSortAlphabetically:
+		return "alphabetical"
+
+	case ListSortNumerically:
+		return "numerical"
+
+	default:
+		return "unknown"
+	}
+}
+
+// remainingKeysPlaceholder can be used in the keys of a key order rule to
+// define where the keys that are not part of the rule are placed
+const remainingKeysPlaceholder = "*"
+
+// KeyOrderRule describes the preferred order of keys in a map. By default, the
+// keys that are not part of the rule follow the keys of the rule, unless the
+// rule contains an asterisk as a placeholder for them, for example `name, *,
+// when` to keep `when` after all unknown keys. A rule can be limited to maps
+// at paths matching a GoPatch style pattern, where an asterisk matches exactly
+// one path element (e.g. `/spec/template/*`) and a double asterisk any number
+// of path elements (e.g. `**/containers/*`), and to Kubernetes objects of a
+// specific kind. If more than one rule applies to a map, the rule with the
+// highest priority is used, and among rules with the same priority the one
+// that has the most keys in common with the map.
+type KeyOrderRule struct {
+	Keys     []string `yaml:"keys"`
+	Priority int      `yaml:"priority,omitempty"`
+	Path     string   `yaml:"path,omitempty"`
+	Kind     string   `yaml:"kind,omitempty"`
+}
+
+// Restructurer rearranges the keys of maps to match established human orders,
+// for example `name` first, based on a set of key order rules and optional
+// profiles for well known document types, which are not used unless they are
+// set, for example to `DefaultRestructureProfiles()`. Optionally, it also
+// sorts named-entry lists by the names of their entries and lists of scalar
+// values, except for the lists at paths matching one of the order sensitive
+// path patterns, for example the `plan` of a Concourse job. Once set up, a
+// restructurer can be used concurrently.
+type Restructurer struct {
+	Rules            []KeyOrderRule
+	Profiles         []RestructureProfile
+	RemainingKeySort SortStrategy
+
+	SortNamedLists      bool
+	ScalarListSort      ListSortStrategy
+	OrderSensitivePaths []string
+}
+
 var knownKeyOrders = [][]string{
 	{"name", "director_uuid", "releases", "instance_groups", "networks", "resource_pools", "compilation"},
 	{"name", "url", "version", "sha1"},
@@ -58,6 +165,104 @@ var knownKeyOrders = [][]string{
 	{"id"},
 }
 
+// DefaultKeyOrderRules returns the built-in key order rules for well known
+// YAML structures like BOSH manifests, Concourse pipelines, or Kubernetes
+func DefaultKeyOrderRules() []KeyOrderRule {
+	rules := make([]KeyOrderRule, len(knownKeyOrders))
+	for i, keys := range knownKeyOrders {
+		rules[i] = KeyOrderRule{Keys: append([]string{}, keys...)}
+	}
+
+	return rules
+}
+
+// DefaultOrderSensitivePaths returns the path patterns of well known lists in
+// which the order of the entries matters, for example Concourse job plans,
+// GitHub Actions steps, Ansible tasks, or Kubernetes init containers, as well
+// as lists that are the document itself, like an Ansible playbook
+func DefaultOrderSensitivePaths() []string {
+	return []string{
+		"/",
+		"**/plan",
+		"**/do",
+		"**/steps",
+		"**/tasks",
+		"**/pre_tasks",
+		"**/post_tasks",
+		"**/handlers",
+		"**/block",
+		"**/initContainers",
+		"**/command",
+		"**/args",
+		"**/entrypoint",
+		"**/env",
+	}
+}
+
+// NewRestructurer creates a restructurer that uses the default key order rules
+// as well as the provided additional rules, but no profiles
+func NewRestructurer(rules ...KeyOrderRule) *Restructurer {
+	return &Restructurer{
+		Rules:               append(DefaultKeyOrderRules(), rules...),
+		RemainingKeySort:    SortByDepth,
+		OrderSensitivePaths: DefaultOrderSensitivePaths(),
+	}
+}
+
+// LoadKeyOrderRules loads key order rules from a YAML file, which contains the
+// rules as a list using the fields `keys`, `priority`, `path`, and `kind`,
+// either at the top level or under a `rules` key:
+//
+//	rules:
+//	- keys: [apiVersion, kind, metadata, spec]
+//	  kind: MyCustomResource
+//	  priority: 10
+func LoadKeyOrderRules(location string) ([]KeyOrderRule, error) {
+	data, err := getBytesFromLocation(location)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load key order rules from %s: %w", HumanReadableLocation(location), err)
+	}
+
+	var node yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("unable to parse key order rules from %s: %w", HumanReadableLocation(location), err)
+	}
+
+	root := documentRoot(&node)
+	if root.Kind == yamlv3.MappingNode {
+		if root, err = getValueByKey(root, "rules"); err != nil {
+			return nil, fmt.Errorf("unable to parse key order rules from %s: %w", HumanReadableLocation(location), err)
+		}
+	}
+
+	var rules []KeyOrderRule
+	if err := root.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("unable to parse key order rules from %s: %w", HumanReadableLocation(location), err)
+	}
+
+	for i, rule := range rules {
+		if len(rule.Keys) == 0 {
+			return nil, fmt.Errorf("unable to parse key order rules from %s: rule #%d has no keys", HumanReadableLocation(location), i+1)
+		}
+	}
+
+	return rules, nil
+}
+
+// appliesTo returns whether the rule can be used for a map at the provided
+// path in a document of the given Kubernetes kind
+func (rule *KeyOrderRule) appliesTo(path Path, kind string) bool {
+	if rule.Kind != "" && rule.Kind != kind {
+		return false
+	}
+
+	if rule.Path != "" && !matchesPattern(patternSections(rule.Path), path.sections()) {
+		return false
+	}
+
+	return true
+}
+
 func lookupMap(list []string) map[string]int {
 	result := make(map[string]int, len(list))
 	for idx, entry := range list {
@@ -106,7 +311,7 @@ func countCommonKeys(keys []string, list []string) (counter int) {
 func commonKeys(setA []string, setB []string) []string {
 	result, lookup := []string{}, lookupMap(setB)
 	for _, entry := range setA {
-		if _, ok := lookup[entry]; ok {
+		if _, ok := lookup[entry]; ok || entry == remainingKeysPlaceholder {
 			result = append(result, entry)
 		}
 	}
@@ -114,9 +319,10 @@ func commonKeys(setA []string, setB []string) []string {
 	return result
 }
 
-func reorderKeyValuePairsInMappingNodeContent(mappingNode *yamlv3.Node, keys []string) {
+func reorderKeyValuePairsInMappingNodeContent(mappingNode *yamlv3.Node, keys []string, strategy SortStrategy) {
 	// Create list with all keys, that are not part of the provided list of keys
 	remainingKeys, keysLookup := []string{}, lookupMap(keys)
+	delete(keysLookup, remainingKeysPlaceholder)
 	for i := 0; i < len(mappingNode.Content); i += 2 {
 		key := mappingNode.Content[i].Value
 		if _, ok := keysLookup[key]; !ok {
@@ -124,20 +330,33 @@ func reorderKeyValuePairsInMappingNodeContent(mappingNode *yamlv3.Node, keys []s
 		}
 	}
 
+	switch strategy {
 	// Sort remaining keys by sorting long and possibly hard to read structure
 	// to the end of the mapping
-	if !DisableRemainingKeySort {
+	case SortByDepth:
 		sort.Slice(remainingKeys, func(i, j int) bool {
 			valI, _ := getValueByKey(mappingNode, remainingKeys[i])
 			valJ, _ := getValueByKey(mappingNode, remainingKeys[j])
 			return maxDepth(valI) < maxDepth(valJ)
 		})
+
+	case SortAlphabetically:
+		sort.Strings(remainingKeys)
 	}
 
 	// Rebuild a new YAML Node list (content) key by key by using first the keys
-	// from the reorder list and then all remaining keys
+	// from the reorder list and then all remaining keys, unless the reorder list
+	// defines a different place for the remaining keys
+	order := append(append([]string{}, keys...), remainingKeys...)
+	for i, key := range keys {
+		if key == remainingKeysPlaceholder {
+			order = append(append(append([]string{}, keys[:i]...), remainingKeys...), keys[i+1:]...)
+			break
+		}
+	}
+
 	content, contentLookup := []*yamlv3.Node{}, lookupMapOfContentList(mappingNode.Content)
-	for _, key := range append(keys, remainingKeys...) {
+	for _, key := range order {
 		idx := contentLookup[key]
 		content = append(content,
 			mappingNode.Content[idx],
@@ -148,50 +367,207 @@ func reorderKeyValuePairsInMappingNodeContent(mappingNode *yamlv3.Node, keys []s
 	mappingNode.Content = content
 }
 
-func getSuitableReorderFunction(keys []string) func(*yamlv3.Node) {
-	topCandidateIdx, topCandidateHits := -1, -1
-	for idx, candidate := range knownKeyOrders {
-		if count := countCommonKeys(keys, candidate); count > 0 && count > topCandidateHits {
-			topCandidateIdx = idx
+// findKeyOrder returns the keys of the most suitable rule for a map with the
+// provided keys, which only contains the keys that are present in the map. The
+// rules of the detected profile are preferred over all other rules.
+func (r *Restructurer) findKeyOrder(profile *RestructureProfile, path Path, kind string, keys []string) ([]string, bool) {
+	if profile != nil {
+		if rule := bestKeyOrderRule(profile.Rules, path, kind, keys); rule != nil {
+			return commonKeys(rule.Keys, keys), true
+		}
+	}
+
+	if rule := bestKeyOrderRule(r.Rules, path, kind, keys); rule != nil {
+		return commonKeys(rule.Keys, keys), true
+	}
+
+	return nil, false
+}
+
+func bestKeyOrderRule(rules []KeyOrderRule, path Path, kind string, keys []string) *KeyOrderRule {
+	var topCandidate *KeyOrderRule
+	var topCandidateHits int
+	for i := range rules {
+		candidate := &rules[i]
+		if !candidate.appliesTo(path, kind) {
+			continue
+		}
+
+		count := countCommonKeys(keys, candidate.Keys)
+		if count == 0 {
+			continue
+		}
+
+		if topCandidate == nil ||
+			candidate.Priority > topCandidate.Priority ||
+			(candidate.Priority == topCandidate.Priority && count > topCandidateHits) {
+			topCandidate = candidate
 			topCandidateHits = count
 		}
 	}
 
-	if topCandidateIdx >= 0 {
-		return func(input *yamlv3.Node) {
-			reorderKeyValuePairsInMappingNodeContent(
-				input,
-				commonKeys(knownKeyOrders[topCandidateIdx], keys),
-			)
+	return topCandidate
+}
+
+// Restructure traverses the provided YAML tree and rearranges the keys of all
+// maps based on the key order rules of the restructurer, and the rules of the
+// first profile that matches the document (see `RestructureProfile`)
+func (r *Restructurer) Restructure(node *yamlv3.Node) {
+	root := documentRoot(node)
+
+	var profile *RestructureProfile
+	for i := range r.Profiles {
+		if r.Profiles[i].Detect(root) {
+			profile = &r.Profiles[i]
+			break
 		}
 	}
 
-	return nil
+	r.restructure(profile, Path{}, "", root)
 }
 
-// RestructureObject takes an object and traverses down any sub elements such as
-// list entries or map values to recursively call restructure itself. On YAML
-// MappingNodes, it will use a look-up mechanism to decide if the order of key
-// in that map need to be rearranged to meet some known established human order.
-func RestructureObject(node *yamlv3.Node) {
+func (r *Restructurer) restructure(profile *RestructureProfile, path Path, kind string, node *yamlv3.Node) {
 	switch node.Kind {
-	case yamlv3.DocumentNode:
-		RestructureObject(node.Content[0])
-
 	case yamlv3.MappingNode:
-		keys := listKeys(node)
-		if fn := getSuitableReorderFunction(keys); fn != nil {
-			fn(node)
+		if objectKind, ok := kubernetesKind(node); ok {
+			kind = objectKind
+		}
+
+		if keys, ok := r.findKeyOrder(profile, path, kind, listKeys(node)); ok {
+			reorderKeyValuePairsInMappingNodeContent(node, keys, r.RemainingKeySort)
 		}
 
 		// Restructure the values of the respective keys of this YAML MapSlice
 		for i := 0; i < len(node.Content); i += 2 {
-			RestructureObject(node.Content[i+1])
+			r.restructure(profile, NewPathWithNamedElement(path, node.Content[i].Value), kind, node.Content[i+1])
 		}
 
 	case yamlv3.SequenceNode:
+		if !r.isOrderSensitive(path) {
+			r.sortSequence(path, node)
+		}
+
 		for i := range node.Content {
-			RestructureObject(node.Content[i])
+			r.restructure(profile, itemPath(path, node, i), kind, node.Content[i])
+		}
+	}
+}
+
+func (r *Restructurer) isOrderSensitive(path Path) bool {
+	sections := path.sections()
+	for _, pattern := range r.OrderSensitivePaths {
+		if matchesPattern(patternSections(pattern), sections) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortSequence sorts named-entry lists by the names of the entries and lists
+// of scalar values based on the configured strategy, other lists such as lists
+// with mixed types are not changed
+func (r *Restructurer) sortSequence(path Path, sequenceNode *yamlv3.Node) {
+	if r.SortNamedLists {
+		if identifier := DefaultIdentifierResolver.Identifier(path, sequenceNode); identifier != nil {
+			names := make(map[*yamlv3.Node][]string, len(sequenceNode.Content))
+			for _, entry := range sequenceNode.Content {
+				names[entry], _ = getNameByIdentifier(entry, identifier)
+			}
+
+			sort.SliceStable(sequenceNode.Content, func(i, j int) bool {
+				return slices.CompareFunc(names[sequenceNode.Content[i]], names[sequenceNode.Content[j]], compareNames) < 0
+			})
+
+			return
+		}
+	}
+
+	if r.ScalarListSort == ListSortNone {
+		return
+	}
+
+	for _, entry := range sequenceNode.Content {
+		if entry.Kind != yamlv3.ScalarNode {
+			return
+		}
+	}
+
+	sort.SliceStable(sequenceNode.Content, func(i, j int) bool {
+		a, b := sequenceNode.Content[i], sequenceNode.Content[j]
+		if r.ScalarListSort == ListSortNumerically {
+			numberA, isNumberA := numberOf(a)
+			numberB, isNumberB := numberOf(b)
+			switch {
+			case isNumberA && isNumberB:
+				return numberA < numberB
+
+			case isNumberA != isNumberB:
+				return isNumberA
+			}
+		}
+
+		return a.Value < b.Value
+	})
+}
+
+// compareNames compares two names of named-entry list entries, where numbers
+// are compared by value and come before all other names, which are compared
+// alphabetically, so that for example port `80` comes before port `443`
+func compareNames(a string, b string) int {
+	numberA, isNumberA := parseNumber(a)
+	numberB, isNumberB := parseNumber(b)
+	switch {
+	case isNumberA && isNumberB:
+		return cmp.Compare(numberA, numberB)
+
+	case isNumberA != isNumberB:
+		if isNumberA {
+			return -1
 		}
+
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+func parseNumber(value string) (float64, bool) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsInf(number, 0) || math.IsNaN(number) {
+		return 0, false
 	}
+
+	return number, true
+}
+
+// kubernetesKind returns the kind of a map that is a Kubernetes object, which
+// means that it has both, an `apiVersion` and a `kind` key
+func kubernetesKind(mappingNode *yamlv3.Node) (string, bool) {
+	if _, err := getValueByKey(mappingNode, "apiVersion"); err != nil {
+		return "", false
+	}
+
+	kind, err := getValueByKey(mappingNode, "kind")
+	if err != nil || kind.Kind != yamlv3.ScalarNode {
+		return "", false
+	}
+
+	return kind.Value, true
+}
+
+// RestructureObject takes an object and traverses down any sub elements such as
+// list entries or map values to recursively call restructure itself. On YAML
+// MappingNodes, it will use a look-up mechanism to decide if the order of key
+// in that map need to be rearranged to meet some known established human order.
+// It uses the default key order rules and the Kubernetes profile to order the
+// nested sections of Kubernetes objects, see `Restructurer` for custom rules.
+func RestructureObject(node *yamlv3.Node) {
+	restructurer := NewRestructurer()
+	restructurer.Profiles = []RestructureProfile{kubernetesProfile()}
+	if DisableRemainingKeySort {
+		restructurer.RemainingKeySort = SortNone
+	}
+
+	restructurer.Restructure(node)
 }
@@ -0,0 +1,288 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"slices"
+	"strings"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// RestructureProfile is a set of key order rules for a well known type of
+// document, which is detected based on the shape of the document. Profiles are
+// opt-in, see `Restructurer`.
+type RestructureProfile struct {
+	Name   string
+	Detect func(root *yamlv3.Node) bool
+	Rules  []KeyOrderRule
+}
+
+// DefaultRestructureProfiles returns the built-in profiles for GitHub Actions
+// workflows, docker-compose files, Helm charts, OpenAPI documents, Ansible
+// playbooks or task files, and Kubernetes objects
+func DefaultRestructureProfiles() []RestructureProfile {
+	return []RestructureProfile{
+		gitHubActionsProfile(),
+		dockerComposeProfile(),
+		helmChartProfile(),
+		openAPIProfile(),
+		ansibleProfile(),
+		kubernetesProfile(),
+	}
+}
+
+// DetectRestructureProfile returns the name of the built-in profile that
+// matches the provided document, if there is one
+func DetectRestructureProfile(node *yamlv3.Node) (string, bool) {
+	root := documentRoot(node)
+	for _, profile := range DefaultRestructureProfiles() {
+		if profile.Detect(root) {
+			return profile.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// https://docs.github.com/en/actions/using-workflows/workflow-syntax-for-github-actions
+func gitHubActionsProfile() RestructureProfile {
+	return RestructureProfile{
+		Name: "GitHub Actions",
+		Detect: func(root *yamlv3.Node) bool {
+			return hasKeys(root, "on", "jobs") && allValuesHaveOneOf(valueOf(root, "jobs"), "runs-on", "steps", "uses")
+		},
+		Rules: []KeyOrderRule{
+			{Path: "/", Keys: []string{"name", "run-name", "on", "permissions", "env", "defaults", "concurrency", "jobs"}},
+			{Path: "/on/*", Keys: []string{"types", "branches", "branches-ignore", "tags", "tags-ignore", "paths", "paths-ignore", "inputs", "outputs", "secrets"}},
+			{Path: "/jobs/*", Keys: []string{"name", "needs", "if", "runs-on", "environment", "permissions", "concurrency", "outputs", "env", "defaults", "timeout-minutes", "continue-on-error", "strategy", "container", "services", "uses", "with", "secrets", "steps"}},
+			{Path: "/jobs/*/strategy", Keys: []string{"matrix", "fail-fast", "max-parallel"}},
+			{Path: "/jobs/*/steps/*", Keys: []string{"name", "id", "if", "uses", "with", "run", "shell", "working-directory", "env", "continue-on-error", "timeout-minutes"}},
+		},
+	}
+}
+
+// https://docs.docker.com/compose/compose-file/
+func dockerComposeProfile() RestructureProfile {
+	return RestructureProfile{
+		Name: "docker-compose",
+		Detect: func(root *yamlv3.Node) bool {
+			return hasKeys(root, "services") && allValuesHaveOneOf(valueOf(root, "services"), "image", "build")
+		},
+		Rules: []KeyOrderRule{
+			{Path: "/", Keys: []string{"version", "name", "services", "networks", "volumes", "configs", "secrets"}},
+			{Path: "/services/*", Keys: []string{"image", "build", "container_name", "hostname", "platform", "command", "entrypoint", "working_dir", "user", "restart", "depends_on", "environment", "env_file", "ports", "expose", "volumes", "networks", "healthcheck", "labels", "logging", "deploy"}},
+			{Path: "/services/*/build", Keys: []string{"context", "dockerfile", "target", "args"}},
+			{Path: "/services/*/healthcheck", Keys: []string{"test", "interval", "timeout", "retries", "start_period"}},
+		},
+	}
+}
+
+// https://helm.sh/docs/topics/charts/#the-chartyaml-file
+func helmChartProfile() RestructureProfile {
+	return RestructureProfile{
+		Name: "Helm Chart",
+		Detect: func(root *yamlv3.Node) bool {
+			if !hasKeys(root, "apiVersion", "name", "version") || hasKeys(root, "kind") {
+				return false
+			}
+
+			apiVersion := valueOf(root, "apiVersion")
+			return apiVersion.Value == "v1" || apiVersion.Value == "v2"
+		},
+		Rules: []KeyOrderRule{
+			{Path: "/", Keys: []string{"apiVersion", "name", "version", "kubeVersion", "description", "type", "keywords", "home", "sources", "dependencies", "maintainers", "icon", "appVersion", "deprecated", "annotations"}},
+			{Path: "/dependencies/*", Keys: []string{"name", "version", "repository", "condition", "tags", "import-values", "alias"}},
+			{Path: "/maintainers/*", Keys: []string{"name", "email", "url"}},
+		},
+	}
+}
+
+// https://spec.openapis.org/oas/latest.html
+func openAPIProfile() RestructureProfile {
+	operation := []string{"tags", "summary", "description", "externalDocs", "operationId", "parameters", "requestBody", "responses", "callbacks", "deprecated", "security", "servers"}
+
+	return RestructureProfile{
+		Name: "OpenAPI",
+		Detect: func(root *yamlv3.Node) bool {
+			return (hasKeys(root, "openapi") || hasKeys(root, "swagger")) && hasKeys(root, "info")
+		},
+		Rules: []KeyOrderRule{
+			{Path: "/", Keys: []string{"openapi", "swagger", "info", "jsonSchemaDialect", "externalDocs", "servers", "host", "basePath", "schemes", "consumes", "produces", "tags", "security", "securityDefinitions", "paths", "webhooks", "components", "definitions", "parameters", "responses"}},
+			{Path: "/info", Keys: []string{"title", "summary", "description", "termsOfService", "contact", "license", "version"}},
+			{Path: "/paths/*", Keys: []string{"$ref", "summary", "description", "servers", "parameters", "get", "put", "post", "delete", "options", "head", "patch", "trace"}},
+			{Path: "/paths/*/*", Keys: operation},
+			{Path: "/paths/*/*/parameters/*", Keys: []string{"name", "in", "description", "required", "deprecated", "schema"}},
+			{Path: "/paths/*/parameters/*", Keys: []string{"name", "in", "description", "required", "deprecated", "schema"}},
+			{Path: "/components/schemas/*", Keys: []string{"title", "description", "type", "format", "required", "properties", "items", "allOf", "anyOf", "oneOf"}},
+		},
+	}
+}
+
+// https://docs.ansible.com/ansible/latest/reference_appendices/playbooks_keywords.html
+func ansibleProfile() RestructureProfile {
+	// Tasks start with the name and the module, followed by the task keywords,
+	// but since modules are arbitrary keys, a placeholder marks their place
+	task := []string{"name", remainingKeysPlaceholder, "args", "when", "loop", "with_items", "loop_control", "register", "changed_when", "failed_when", "ignore_errors", "become", "become_user", "delegate_to", "run_once", "notify", "tags"}
+	play := []string{"name", "hosts", "gather_facts", "become", "become_user", "vars", "vars_files", "pre_tasks", "roles", "tasks", "post_tasks", "handlers"}
+
+	var rules []KeyOrderRule
+	for _, path := range []string{"/*", "/*/pre_tasks/*", "/*/tasks/*", "/*/post_tasks/*", "/*/handlers/*", "/*/block/*", "/*/tasks/*/block/*"} {
+		rules = append(rules, KeyOrderRule{Path: path, Keys: task})
+	}
+
+	// Plays and tasks are both root level entries, in case both rules have the
+	// same number of common keys, the task rule defined earlier is used
+	rules = append(rules, KeyOrderRule{Path: "/*", Keys: play})
+
+	return RestructureProfile{
+		Name: "Ansible",
+		Detect: func(root *yamlv3.Node) bool {
+			if root.Kind != yamlv3.SequenceNode || len(root.Content) == 0 {
+				return false
+			}
+
+			for _, entry := range root.Content {
+				if entry.Kind != yamlv3.MappingNode {
+					return false
+				}
+			}
+
+			for _, entry := range root.Content {
+				for _, key := range listKeys(entry) {
+					switch {
+					case strings.HasPrefix(key, "ansible.builtin."),
+						slices.Contains([]string{"hosts", "tasks", "roles", "import_playbook", "block", "include_tasks", "import_tasks", "when", "register", "notify", "become", "with_items", "loop"}, key):
+						return true
+					}
+				}
+			}
+
+			return false
+		},
+		Rules: rules,
+	}
+}
+
+// kubernetesKeyOrderRules is the rule table for nested sections of Kubernetes
+// objects, which start with a double asterisk to match both objects that are
+// the document itself and the items of a list object
+var kubernetesKeyOrderRules = []KeyOrderRule{
+	{Keys: []string{"apiVersion", "kind", "metadata", "type", "spec", "data", "stringData", "binaryData", "rules", "subjects", "roleRef", "status"}},
+	{Path: "**/metadata", Keys: []string{"name", "generateName", "namespace", "labels", "annotations", "ownerReferences", "finalizers"}},
+	{Path: "**/selector", Keys: []string{"matchLabels", "matchExpressions"}},
+	{Path: "**/template", Keys: []string{"metadata", "spec"}},
+
+	// Workload resources
+	{Path: "**/spec", Kind: "Deployment", Keys: []string{"replicas", "selector", "strategy", "minReadySeconds", "revisionHistoryLimit", "progressDeadlineSeconds", "paused", "template"}},
+	{Path: "**/spec", Kind: "StatefulSet", Keys: []string{"serviceName", "replicas", "selector", "podManagementPolicy", "updateStrategy", "minReadySeconds", "revisionHistoryLimit", "template", "volumeClaimTemplates"}},
+	{Path: "**/spec", Kind: "DaemonSet", Keys: []string{"selector", "updateStrategy", "minReadySeconds", "revisionHistoryLimit", "template"}},
+	{Path: "**/spec", Kind: "ReplicaSet", Keys: []string{"replicas", "selector", "minReadySeconds", "template"}},
+	{Path: "**/spec", Kind: "Job", Keys: []string{"parallelism", "completions", "completionMode", "backoffLimit", "activeDeadlineSeconds", "ttlSecondsAfterFinished", "suspend", "selector", "template"}},
+	{Path: "**/spec", Kind: "CronJob", Keys: []string{"schedule", "timeZone", "concurrencyPolicy", "suspend", "startingDeadlineSeconds", "successfulJobsHistoryLimit", "failedJobsHistoryLimit", "jobTemplate"}},
+	{Path: "**/jobTemplate/spec", Kind: "CronJob", Keys: []string{"parallelism", "completions", "backoffLimit", "activeDeadlineSeconds", "ttlSecondsAfterFinished", "template"}},
+
+	// Pod specifications and containers
+	{Path: "**/spec", Kind: "Pod", Keys: podSpecKeys},
+	{Path: "**/template/spec", Keys: podSpecKeys},
+	{Path: "**/initContainers/*", Keys: containerKeys},
+	{Path: "**/containers/*", Keys: containerKeys},
+	{Path: "**/ephemeralContainers/*", Keys: containerKeys},
+	{Path: "**/containers/*/ports/*", Keys: []string{"name", "containerPort", "hostPort", "protocol"}},
+	{Path: "**/env/*", Keys: []string{"name", "value", "valueFrom"}},
+	{Path: "**/volumeMounts/*", Keys: []string{"name", "mountPath", "subPath", "readOnly"}},
+	{Path: "**/resources", Keys: []string{"requests", "limits"}},
+	{Path: "**/livenessProbe", Keys: probeKeys},
+	{Path: "**/readinessProbe", Keys: probeKeys},
+	{Path: "**/startupProbe", Keys: probeKeys},
+
+	// Services and ingresses
+	{Path: "**/spec", Kind: "Service", Keys: []string{"type", "clusterIP", "selector", "ports", "externalTrafficPolicy", "sessionAffinity"}},
+	{Path: "**/ports/*", Kind: "Service", Keys: []string{"name", "protocol", "port", "targetPort", "nodePort"}},
+	{Path: "**/spec", Kind: "Ingress", Keys: []string{"ingressClassName", "defaultBackend", "tls", "rules"}},
+	{Path: "**/rules/*", Kind: "Ingress", Keys: []string{"host", "http"}},
+	{Path: "**/paths/*", Kind: "Ingress", Keys: []string{"path", "pathType", "backend"}},
+}
+
+var podSpecKeys = []string{"serviceAccountName", "automountServiceAccountToken", "restartPolicy", "terminationGracePeriodSeconds", "nodeSelector", "affinity", "tolerations", "securityContext", "imagePullSecrets", "initContainers", "containers", "volumes"}
+
+var containerKeys = []string{"name", "image", "imagePullPolicy", "command", "args", "workingDir", "ports", "env", "envFrom", "resources", "volumeMounts", "volumeDevices", "livenessProbe", "readinessProbe", "startupProbe", "lifecycle", "securityContext", "stdin", "tty"}
+
+var probeKeys = []string{"exec", "httpGet", "tcpSocket", "grpc", "initialDelaySeconds", "periodSeconds", "timeoutSeconds", "successThreshold", "failureThreshold"}
+
+// https://kubernetes.io/docs/reference/kubernetes-api/
+func kubernetesProfile() RestructureProfile {
+	return RestructureProfile{
+		Name: "Kubernetes",
+		Detect: func(root *yamlv3.Node) bool {
+			_, ok := kubernetesKind(root)
+			return ok
+		},
+		Rules: append([]KeyOrderRule{}, kubernetesKeyOrderRules...),
+	}
+}
+
+func valueOf(mappingNode *yamlv3.Node, key string) *yamlv3.Node {
+	if mappingNode.Kind != yamlv3.MappingNode {
+		return nil
+	}
+
+	value, err := getValueByKey(mappingNode, key)
+	if err != nil {
+		return nil
+	}
+
+	return value
+}
+
+func hasKeys(mappingNode *yamlv3.Node, keys ...string) bool {
+	for _, key := range keys {
+		if valueOf(mappingNode, key) == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// allValuesHaveOneOf returns whether the provided node is a non-empty map, in
+// which all values are maps with at least one of the provided keys
+func allValuesHaveOneOf(mappingNode *yamlv3.Node, keys ...string) bool {
+	if mappingNode == nil || mappingNode.Kind != yamlv3.MappingNode || len(mappingNode.Content) == 0 {
+		return false
+	}
+
+	for i := 1; i < len(mappingNode.Content); i += 2 {
+		found := false
+		for _, key := range keys {
+			if valueOf(mappingNode.Content[i], key) != nil {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
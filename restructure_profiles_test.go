@@ -0,0 +1,265 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+
+	"go.yaml.in/yaml/v3"
+)
+
+var _ = Describe("Restructure profiles", func() {
+	keysOf := func(node *yaml.Node, path string) []string {
+		value, err := Grab(node, path)
+		Expect(err).ToNot(HaveOccurred())
+
+		keys, err := ListStringKeys(value)
+		Expect(err).ToNot(HaveOccurred())
+		return keys
+	}
+
+	restructure := func(input string) *yaml.Node {
+		node := yml(input)
+		restructurer := NewRestructurer()
+		restructurer.Profiles = DefaultRestructureProfiles()
+		restructurer.Restructure(node)
+		return node
+	}
+
+	Context("detecting the profile based on the document shape", func() {
+		DescribeTable("should detect well known document types",
+			func(input string, expected string) {
+				name, ok := DetectRestructureProfile(yml(input))
+				Expect(ok).To(BeTrue())
+				Expect(name).To(Equal(expected))
+			},
+
+			Entry("GitHub Actions", `{ on: push, jobs: { build: { runs-on: ubuntu-latest, steps: [] } } }`, "GitHub Actions"),
+			Entry("docker-compose", `{ services: { web: { image: nginx }, db: { build: . } } }`, "docker-compose"),
+			Entry("Helm Chart", `{ apiVersion: v2, name: foo, version: 1.0.0 }`, "Helm Chart"),
+			Entry("OpenAPI", `{ openapi: 3.1.0, info: { title: foo, version: 1 }, paths: {} }`, "OpenAPI"),
+			Entry("Ansible playbook", `[ { hosts: all, tasks: [] } ]`, "Ansible"),
+			Entry("Ansible tasks", `[ { name: foo, ansible.builtin.debug: { msg: hi } } ]`, "Ansible"),
+			Entry("Kubernetes", `{ apiVersion: v1, kind: ConfigMap, metadata: { name: foo } }`, "Kubernetes"),
+		)
+
+		DescribeTable("should not detect a profile for other documents",
+			func(input string) {
+				_, ok := DetectRestructureProfile(yml(input))
+				Expect(ok).To(BeFalse())
+			},
+
+			Entry("Concourse", `{ jobs: [ { name: foo, plan: [] } ], resources: [] }`),
+			Entry("plain list", `[ foo, bar ]`),
+			Entry("services without images", `{ services: { foo: { enabled: true } } }`),
+		)
+	})
+
+	Context("restructuring documents using the detected profile", func() {
+		It("should order GitHub Actions workflows", func() {
+			node := restructure(`---
+jobs:
+  build:
+    steps:
+    - run: make
+      name: Build
+    - with:
+        go-version: stable
+      uses: actions/setup-go@v5
+      name: Setup
+    runs-on: ubuntu-latest
+    needs: [lint]
+env:
+  FOO: bar
+on: push
+name: CI
+`)
+			Expect(keysOf(node, "/")).To(Equal([]string{"name", "on", "env", "jobs"}))
+			Expect(keysOf(node, "/jobs/build")).To(Equal([]string{"needs", "runs-on", "steps"}))
+			Expect(keysOf(node, "/jobs/build/steps/name=Build")).To(Equal([]string{"name", "run"}))
+			Expect(keysOf(node, "/jobs/build/steps/name=Setup")).To(Equal([]string{"name", "uses", "with"}))
+		})
+
+		It("should order docker-compose services", func() {
+			node := restructure(`{ volumes: {}, services: { web: { ports: [ "80:80" ], environment: { A: b }, image: nginx, depends_on: [ db ] }, db: { image: postgres } } }`)
+			Expect(keysOf(node, "/")).To(Equal([]string{"services", "volumes"}))
+			Expect(keysOf(node, "/services/web")).To(Equal([]string{"image", "depends_on", "environment", "ports"}))
+		})
+
+		It("should order Helm Chart.yaml files", func() {
+			node := restructure(`{ appVersion: 1.2.3, dependencies: [ { repository: "https://example.com", version: 1.0.0, name: redis } ], version: 0.1.0, description: foo, name: chart, apiVersion: v2 }`)
+			Expect(keysOf(node, "/")).To(Equal([]string{"apiVersion", "name", "version", "description", "dependencies", "appVersion"}))
+			Expect(keysOf(node, "/dependencies/name=redis")).To(Equal([]string{"name", "version", "repository"}))
+		})
+
+		It("should order OpenAPI documents", func() {
+			node := restructure(`---
+paths:
+  /pets:
+    get:
+      responses: {}
+      operationId: listPets
+      parameters:
+      - in: query
+        schema: { type: integer }
+        name: limit
+      summary: List all pets
+info:
+  version: 1.0.0
+  title: Petstore
+openapi: 3.1.0
+`)
+			Expect(keysOf(node, "/")).To(Equal([]string{"openapi", "info", "paths"}))
+			Expect(keysOf(node, "/info")).To(Equal([]string{"title", "version"}))
+
+			operation, err := Grab(node, "/paths")
+			Expect(err).ToNot(HaveOccurred())
+			operation = operation.Content[1].Content[1]
+			Expect(ListStringKeys(operation)).To(Equal([]string{"summary", "operationId", "parameters", "responses"}))
+			Expect(ListStringKeys(operation.Content[5].Content[0])).To(Equal([]string{"name", "in", "schema"}))
+		})
+
+		It("should order Ansible playbooks and tasks", func() {
+			node := restructure(`---
+- tasks:
+  - when: ansible_os_family == "Debian"
+    ansible.builtin.apt:
+      name: nginx
+    register: result
+    name: Install nginx
+  become: true
+  hosts: webservers
+  name: Setup
+`)
+			Expect(keysOf(node, "/0")).To(Equal([]string{"name", "hosts", "become", "tasks"}))
+			Expect(keysOf(node, "/0/tasks/0")).To(Equal([]string{"name", "ansible.builtin.apt", "when", "register"}))
+		})
+
+		It("should not use profiles unless they are part of the restructurer", func() {
+			node := yml(`{ volumes: {}, services: { web: { ports: [], image: nginx } } }`)
+			NewRestructurer().Restructure(node)
+			Expect(keysOf(node, "/services/web")).To(Equal([]string{"ports", "image"}))
+
+			node = yml(`{ volumes: {}, services: { web: { ports: [], image: nginx } } }`)
+			RestructureObject(node)
+			Expect(keysOf(node, "/services/web")).To(Equal([]string{"ports", "image"}))
+		})
+	})
+
+	Context("restructuring nested sections of Kubernetes objects", func() {
+		It("should order metadata, pod specs, and containers", func() {
+			node := restructure(`---
+spec:
+  template:
+    spec:
+      volumes: []
+      containers:
+      - volumeMounts:
+        - readOnly: true
+          mountPath: /etc/nginx
+          name: config
+        ports:
+        - protocol: TCP
+          containerPort: 80
+          name: http
+        env:
+        - value: bar
+          name: FOO
+        args: [--debug]
+        image: nginx
+        resources:
+          limits: { cpu: 1 }
+          requests: { cpu: 100m }
+        name: web
+      serviceAccountName: web
+    metadata:
+      labels: { app: web }
+  selector:
+    matchLabels: { app: web }
+  replicas: 3
+metadata:
+  labels: { app: web }
+  namespace: default
+  name: web
+kind: Deployment
+apiVersion: apps/v1
+`)
+			Expect(keysOf(node, "/")).To(Equal([]string{"apiVersion", "kind", "metadata", "spec"}))
+			Expect(keysOf(node, "/metadata")).To(Equal([]string{"name", "namespace", "labels"}))
+			Expect(keysOf(node, "/spec")).To(Equal([]string{"replicas", "selector", "template"}))
+			Expect(keysOf(node, "/spec/template")).To(Equal([]string{"metadata", "spec"}))
+			Expect(keysOf(node, "/spec/template/spec")).To(Equal([]string{"serviceAccountName", "containers", "volumes"}))
+			Expect(keysOf(node, "/spec/template/spec/containers/name=web")).To(Equal([]string{"name", "image", "args", "ports", "env", "resources", "volumeMounts"}))
+			Expect(keysOf(node, "/spec/template/spec/containers/name=web/ports/name=http")).To(Equal([]string{"name", "containerPort", "protocol"}))
+			Expect(keysOf(node, "/spec/template/spec/containers/name=web/env/name=FOO")).To(Equal([]string{"name", "value"}))
+			Expect(keysOf(node, "/spec/template/spec/containers/name=web/volumeMounts/name=config")).To(Equal([]string{"name", "mountPath", "readOnly"}))
+			Expect(keysOf(node, "/spec/template/spec/containers/name=web/resources")).To(Equal([]string{"requests", "limits"}))
+		})
+
+		It("should order nested sections of Kubernetes objects by default", func() {
+			node := yml(`{ apiVersion: apps/v1, kind: Deployment, metadata: { annotations: {}, labels: {}, namespace: default, name: web }, spec: { template: { spec: { containers: [ { command: [nginx], args: [--debug], image: nginx, name: web } ] } } } }`)
+			RestructureObject(node)
+			Expect(keysOf(node, "/metadata")).To(Equal([]string{"name", "namespace", "labels", "annotations"}))
+			Expect(keysOf(node, "/spec/template/spec/containers/name=web")).To(Equal([]string{"name", "image", "command", "args"}))
+		})
+
+		It("should use the kind to order the spec of an object", func() {
+			node := restructure(`{ apiVersion: v1, kind: Service, spec: { ports: [ { targetPort: 8080, port: 80, name: http } ], selector: { app: web }, type: ClusterIP } }`)
+			Expect(keysOf(node, "/spec")).To(Equal([]string{"type", "selector", "ports"}))
+			Expect(keysOf(node, "/spec/ports/name=http")).To(Equal([]string{"name", "port", "targetPort"}))
+		})
+
+		It("should order deeply nested pod templates", func() {
+			node := restructure(`{ apiVersion: batch/v1, kind: CronJob, spec: { jobTemplate: { spec: { template: { spec: { containers: [ { image: busybox, name: job } ], restartPolicy: Never } } } }, schedule: "@daily" } }`)
+			Expect(keysOf(node, "/spec")).To(Equal([]string{"schedule", "jobTemplate"}))
+			Expect(keysOf(node, "/spec/jobTemplate/spec/template/spec")).To(Equal([]string{"restartPolicy", "containers"}))
+			Expect(keysOf(node, "/spec/jobTemplate/spec/template/spec/containers/name=job")).To(Equal([]string{"name", "image"}))
+		})
+
+		It("should order the items of list objects", func() {
+			node := restructure(`{ apiVersion: v1, kind: List, items: [ { spec: { selector: { app: web }, type: NodePort }, metadata: { namespace: default, name: web }, kind: Service, apiVersion: v1 } ] }`)
+			Expect(keysOf(node, "/")).To(Equal([]string{"apiVersion", "kind", "items"}))
+			Expect(keysOf(node, "/items/0")).To(Equal([]string{"apiVersion", "kind", "metadata", "spec"}))
+			Expect(keysOf(node, "/items/0/metadata")).To(Equal([]string{"name", "namespace"}))
+			Expect(keysOf(node, "/items/0/spec")).To(Equal([]string{"type", "selector"}))
+		})
+
+		It("should prefer the profile rules over the rules of the restructurer", func() {
+			rule := KeyOrderRule{Path: "/metadata", Kind: "Widget", Keys: []string{"labels", "name"}, Priority: 1}
+
+			node := yml(`{ apiVersion: v1, kind: Widget, metadata: { name: foo, labels: {} } }`)
+			restructurer := NewRestructurer(rule)
+			restructurer.Profiles = DefaultRestructureProfiles()
+			restructurer.Restructure(node)
+			Expect(keysOf(node, "/metadata")).To(Equal([]string{"name", "labels"}))
+
+			for i := range restructurer.Profiles {
+				restructurer.Profiles[i].Rules = append(restructurer.Profiles[i].Rules, rule)
+			}
+
+			restructurer.Restructure(node)
+			Expect(keysOf(node, "/metadata")).To(Equal([]string{"labels", "name"}))
+		})
+	})
+})
@@ -127,4 +127,154 @@ var _ = Describe("Restructure order of map keys", func() {
 			})
 		})
 	})
+
+	Context("using a restructurer with custom rules", func() {
+		keysOf := func(node *yaml.Node, path string) []string {
+			value, err := Grab(node, path)
+			Expect(err).ToNot(HaveOccurred())
+
+			keys, err := ListStringKeys(value)
+			Expect(err).ToNot(HaveOccurred())
+			return keys
+		}
+
+		It("should use custom rules in addition to the default rules", func() {
+			example := yml(`{ color: red, size: 1, name: foo }`)
+			NewRestructurer(KeyOrderRule{Keys: []string{"size", "color"}}).Restructure(example)
+			Expect(keysOf(example, "/")).To(Equal([]string{"size", "color", "name"}))
+		})
+
+		It("should prefer rules with a higher priority", func() {
+			example := yml(`{ b: 1, a: 2, c: 3 }`)
+			NewRestructurer(
+				KeyOrderRule{Keys: []string{"a", "b", "c"}},
+				KeyOrderRule{Keys: []string{"c"}, Priority: 1},
+			).Restructure(example)
+			Expect(keysOf(example, "/")).To(Equal([]string{"c", "b", "a"}))
+		})
+
+		It("should only apply rules scoped to a path at matching paths", func() {
+			example := yml(`{ spec: { b: 1, a: 2 }, other: { b: 1, a: 2 }, list: [ { b: 1, a: 2 } ] }`)
+			NewRestructurer(
+				KeyOrderRule{Keys: []string{"a", "b"}, Path: "/spec"},
+				KeyOrderRule{Keys: []string{"a", "b"}, Path: "/list/*"},
+			).Restructure(example)
+			Expect(keysOf(example, "/spec")).To(Equal([]string{"a", "b"}))
+			Expect(keysOf(example, "/other")).To(Equal([]string{"b", "a"}))
+			Expect(keysOf(example, "/list/0")).To(Equal([]string{"a", "b"}))
+		})
+
+		It("should only apply rules scoped to a Kubernetes kind in objects of that kind", func() {
+			rule := KeyOrderRule{Keys: []string{"spec", "metadata"}, Kind: "Widget", Priority: 1}
+
+			widget := yml(`{ metadata: {}, kind: Widget, spec: {}, apiVersion: v1 }`)
+			NewRestructurer(rule).Restructure(widget)
+			Expect(keysOf(widget, "/")).To(Equal([]string{"spec", "metadata", "kind", "apiVersion"}))
+
+			gadget := yml(`{ metadata: {}, kind: Gadget, spec: {}, apiVersion: v1 }`)
+			NewRestructurer(rule).Restructure(gadget)
+			Expect(keysOf(gadget, "/")).To(Equal([]string{"apiVersion", "kind", "metadata", "spec"}))
+		})
+
+		It("should sort the remaining keys using the configured strategy", func() {
+			input := `{ deep: { a: { b: 1 } }, name: foo, zulu: 1, alpha: 2 }`
+
+			restructurer := NewRestructurer()
+			example := yml(input)
+			restructurer.Restructure(example)
+			Expect(keysOf(example, "/")).To(Equal([]string{"name", "zulu", "alpha", "deep"}))
+
+			restructurer.RemainingKeySort = SortAlphabetically
+			example = yml(input)
+			restructurer.Restructure(example)
+			Expect(keysOf(example, "/")).To(Equal([]string{"name", "alpha", "deep", "zulu"}))
+
+			restructurer.RemainingKeySort = SortNone
+			example = yml(input)
+			restructurer.Restructure(example)
+			Expect(keysOf(example, "/")).To(Equal([]string{"name", "deep", "zulu", "alpha"}))
+		})
+
+		It("should load rules from a YAML file", func() {
+			rules, err := LoadKeyOrderRules(assets("restructure", "rules.yml"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rules).To(HaveLen(2))
+			Expect(rules[0].Kind).To(Equal("Widget"))
+			Expect(rules[1].Path).To(Equal("/spec"))
+
+			example := yml(`{ spec: { shape: round, color: red, size: 1 }, kind: Widget, apiVersion: v1, metadata: {} }`)
+			NewRestructurer(rules...).Restructure(example)
+			Expect(keysOf(example, "/")).To(Equal([]string{"apiVersion", "kind", "metadata", "spec"}))
+			Expect(keysOf(example, "/spec")).To(Equal([]string{"size", "color", "shape"}))
+		})
+
+		It("should fail to load rules from an invalid file", func() {
+			_, err := LoadKeyOrderRules(assets("restructure", "does-not-exist.yml"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("using a restructurer that sorts lists", func() {
+		It("should not sort lists by default", func() {
+			example := yml(`{ list: [ b, a ], named: [ { name: b }, { name: a } ] }`)
+			NewRestructurer().Restructure(example)
+			Expect(example).To(BeAsNode(yml(`{ list: [ b, a ], named: [ { name: b }, { name: a } ] }`)))
+		})
+
+		It("should sort named-entry lists by the names of the entries", func() {
+			example := yml(`{ jobs: [ { name: web, instances: 2 }, { name: api }, { name: db } ], plain: [ { foo: b }, { foo: a } ] }`)
+
+			restructurer := NewRestructurer()
+			restructurer.SortNamedLists = true
+			restructurer.Restructure(example)
+
+			Expect(example).To(BeAsNode(yml(`{ jobs: [ { name: api }, { name: db }, { name: web, instances: 2 } ], plain: [ { foo: b }, { foo: a } ] }`)))
+		})
+
+		It("should sort named-entry lists with numeric names by value", func() {
+			example := yml(`{ ports: [ { name: 8080 }, { name: 443 }, { name: http }, { name: 80 } ] }`)
+
+			restructurer := NewRestructurer()
+			restructurer.SortNamedLists = true
+			restructurer.Restructure(example)
+
+			Expect(example).To(BeAsNode(yml(`{ ports: [ { name: 80 }, { name: 443 }, { name: 8080 }, { name: http } ] }`)))
+		})
+
+		It("should sort lists of scalars alphabetically", func() {
+			example := yml(`{ list: [ b, 10, a, 9 ], mixed: [ b, { a: 1 } ] }`)
+
+			restructurer := NewRestructurer()
+			restructurer.ScalarListSort = ListSortAlphabetically
+			restructurer.Restructure(example)
+
+			Expect(example).To(BeAsNode(yml(`{ list: [ 10, 9, a, b ], mixed: [ b, { a: 1 } ] }`)))
+		})
+
+		It("should sort lists of scalars numerically", func() {
+			example := yml(`{ list: [ b, 10, a, 9, 0x1, 2.5 ] }`)
+
+			restructurer := NewRestructurer()
+			restructurer.ScalarListSort = ListSortNumerically
+			restructurer.Restructure(example)
+
+			Expect(example).To(BeAsNode(yml(`{ list: [ 0x1, 2.5, 9, 10, a, b ] }`)))
+		})
+
+		It("should not sort lists at order sensitive paths", func() {
+			example := yml(`{ jobs: [ { name: b, plan: [ { get: z }, { get: a } ] }, { name: a, plan: [] } ], command: [ run, --debug ] }`)
+
+			restructurer := NewRestructurer()
+			restructurer.SortNamedLists = true
+			restructurer.ScalarListSort = ListSortAlphabetically
+			restructurer.Restructure(example)
+
+			Expect(example).To(BeAsNode(yml(`{ jobs: [ { name: a, plan: [] }, { name: b, plan: [ { get: z }, { get: a } ] } ], command: [ run, --debug ] }`)))
+
+			restructurer.OrderSensitivePaths = []string{"/jobs"}
+			example = yml(`{ jobs: [ { name: b }, { name: a } ], command: [ run, --debug ] }`)
+			restructurer.Restructure(example)
+			Expect(example).To(BeAsNode(yml(`{ jobs: [ { name: b }, { name: a } ], command: [ --debug, run ] }`)))
+		})
+	})
 })
@@ -0,0 +1,928 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// JSON Schema type names
+const (
+	jsonTypeObject  = "object"
+	jsonTypeArray   = "array"
+	jsonTypeString  = "string"
+	jsonTypeNumber  = "number"
+	jsonTypeInteger = "integer"
+	jsonTypeBoolean = "boolean"
+	jsonTypeNull    = "null"
+)
+
+// maxSchemaDepth limits the nesting of schema evaluations to detect schemas
+// with infinite recursion through references
+const maxSchemaDepth = 512
+
+// SchemaViolation describes a section of a document that does not conform to
+// a JSON Schema, including the position of that section in the source.
+type SchemaViolation struct {
+	Path    Path
+	Line    int
+	Column  int
+	Keyword string
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	return fmt.Sprintf("%s (line %d, column %d): %s",
+		v.Path.ToGoPatchStyle(),
+		v.Line,
+		v.Column,
+		v.Message,
+	)
+}
+
+// ValidateAgainstSchema validates the provided document against a JSON Schema
+// (draft 2020-12), which itself is a YAML (or JSON) document. All violations
+// are returned with the path and position of the offending section. An error
+// is returned if the schema itself cannot be used, for example because of an
+// invalid regular expression or a reference that cannot be resolved. Remote
+// references and `$dynamicRef` are not supported and the `format` keyword is
+// only an annotation.
+func ValidateAgainstSchema(doc *yamlv3.Node, schema *yamlv3.Node) ([]SchemaViolation, error) {
+	validator := newSchemaValidator(documentRoot(schema))
+
+	result, err := validator.validate(validator.root, documentRoot(doc), Path{}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.violations, nil
+}
+
+type schemaValidator struct {
+	root    *yamlv3.Node
+	anchors map[string]*yamlv3.Node
+	ids     map[string]*yamlv3.Node
+	regexps map[string]*regexp.Regexp
+}
+
+// evaluation is the result of validating an instance against a (sub)schema,
+// which besides the violations keeps track of the object properties and array
+// items that were evaluated, as required for the unevaluated keywords
+type evaluation struct {
+	violations []SchemaViolation
+	properties map[string]struct{}
+	items      map[int]struct{}
+}
+
+func newEvaluation() *evaluation {
+	return &evaluation{
+		properties: map[string]struct{}{},
+		items:      map[int]struct{}{},
+	}
+}
+
+func (e *evaluation) valid() bool {
+	return len(e.violations) == 0
+}
+
+// merge adds the violations of the other evaluation, while its evaluated
+// properties and items are only kept if it is valid, since failed subschemas
+// do not produce annotations
+func (e *evaluation) merge(other *evaluation) {
+	e.violations = append(e.violations, other.violations...)
+	if other.valid() {
+		e.mergeAnnotations(other)
+	}
+}
+
+func (e *evaluation) mergeAnnotations(other *evaluation) {
+	for property := range other.properties {
+		e.properties[property] = struct{}{}
+	}
+
+	for item := range other.items {
+		e.items[item] = struct{}{}
+	}
+}
+
+func (e *evaluation) report(path Path, node *yamlv3.Node, keyword string, format string, a ...interface{}) {
+	e.violations = append(e.violations, SchemaViolation{
+		Path:    path,
+		Line:    node.Line,
+		Column:  node.Column,
+		Keyword: keyword,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+func newSchemaValidator(root *yamlv3.Node) *schemaValidator {
+	validator := &schemaValidator{
+		root:    root,
+		anchors: map[string]*yamlv3.Node{},
+		ids:     map[string]*yamlv3.Node{},
+		regexps: map[string]*regexp.Regexp{},
+	}
+
+	validator.register(root)
+	return validator
+}
+
+// register collects all anchors and identifiers of (sub)schemas so that they
+// can be used as reference targets
+func (v *schemaValidator) register(node *yamlv3.Node) {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			k, value := node.Content[i], node.Content[i+1]
+			switch k.Value {
+			case "$anchor", "$dynamicAnchor":
+				v.anchors[value.Value] = node
+
+			case "$id":
+				v.ids[strings.TrimSuffix(value.Value, "#")] = node
+			}
+
+			switch k.Value {
+			// The values of enum and const are instances, not schemas
+			case "enum", "const":
+
+			// The keys of these maps are names, only their values are schemas
+			case "properties", "patternProperties", "dependentSchemas", "$defs", "definitions":
+				if value.Kind == yamlv3.MappingNode {
+					for j := 1; j < len(value.Content); j += 2 {
+						v.register(value.Content[j])
+					}
+				}
+
+			default:
+				v.register(value)
+			}
+		}
+
+	case yamlv3.SequenceNode:
+		for _, entry := range node.Content {
+			v.register(entry)
+		}
+	}
+}
+
+func (v *schemaValidator) resolveReference(ref string) (*yamlv3.Node, error) {
+	base, fragment := ref, ""
+	if idx := strings.Index(ref, "#"); idx >= 0 {
+		base, fragment = ref[:idx], ref[idx+1:]
+	}
+
+	target := v.root
+	if base != "" {
+		node, ok := v.ids[base]
+		if !ok {
+			return nil, fmt.Errorf("failed to resolve schema reference %s, remote references are not supported", ref)
+		}
+
+		target = node
+	}
+
+	switch {
+	case fragment == "":
+		return target, nil
+
+	case strings.HasPrefix(fragment, "/"):
+		return resolveJSONPointer(target, fragment)
+
+	default:
+		node, ok := v.anchors[fragment]
+		if !ok {
+			return nil, fmt.Errorf("failed to resolve schema reference %s, there is no anchor %s", ref, fragment)
+		}
+
+		return node, nil
+	}
+}
+
+func resolveJSONPointer(node *yamlv3.Node, pointer string) (*yamlv3.Node, error) {
+	for _, token := range strings.Split(pointer, "/")[1:] {
+		token, err := url.PathUnescape(token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve JSON pointer %s: %w", pointer, err)
+		}
+
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch node.Kind {
+		case yamlv3.MappingNode:
+			if node, err = getValueByKey(node, token); err != nil {
+				return nil, fmt.Errorf("failed to resolve JSON pointer %s: %w", pointer, err)
+			}
+
+		case yamlv3.SequenceNode:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(node.Content) {
+				return nil, fmt.Errorf("failed to resolve JSON pointer %s, invalid list index %s", pointer, token)
+			}
+
+			node = node.Content[idx]
+
+		default:
+			return nil, fmt.Errorf("failed to resolve JSON pointer %s, cannot traverse into %s", pointer, TypeOf(node))
+		}
+	}
+
+	return node, nil
+}
+
+func (v *schemaValidator) regexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := v.regexps[pattern]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema pattern %s: %w", pattern, err)
+	}
+
+	v.regexps[pattern] = re
+	return re, nil
+}
+
+func (v *schemaValidator) validate(schema *yamlv3.Node, instance *yamlv3.Node, path Path, depth int) (*evaluation, error) {
+	if depth > maxSchemaDepth {
+		return nil, fmt.Errorf("failed to validate, schema nesting exceeds %d levels", maxSchemaDepth)
+	}
+
+	if instance.Kind == yamlv3.AliasNode && instance.Alias != nil {
+		instance = instance.Alias
+	}
+
+	result := newEvaluation()
+
+	switch {
+	case schema.Kind == yamlv3.ScalarNode && schema.ShortTag() == tagBool:
+		if isFalseSchema(schema) {
+			result.report(path, instance, "false", "value is not allowed")
+		}
+
+		return result, nil
+
+	case schema.Kind != yamlv3.MappingNode:
+		return nil, fmt.Errorf("failed to validate, schema must be a map or a boolean, but found %s", TypeOf(schema))
+	}
+
+	keywords := map[string]*yamlv3.Node{}
+	for i := 0; i < len(schema.Content); i += 2 {
+		keywords[schema.Content[i].Value] = schema.Content[i+1]
+	}
+
+	// Dynamic references depend on the dynamic scope of the evaluation, which
+	// is not tracked, so resolving them like a plain `$ref` could be wrong
+	if _, ok := keywords["$dynamicRef"]; ok {
+		return nil, fmt.Errorf("failed to validate, $dynamicRef is not supported")
+	}
+
+	if ref, ok := keywords["$ref"]; ok {
+		target, err := v.resolveReference(ref.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		sub, err := v.validate(target, instance, path, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		result.merge(sub)
+	}
+
+	checks := []func(map[string]*yamlv3.Node, *yamlv3.Node, Path, *evaluation, int) error{
+		v.checkGeneric,
+		v.checkNumber,
+		v.checkString,
+		v.checkArray,
+		v.checkObject,
+		v.checkApplicators,
+		v.checkUnevaluated,
+	}
+
+	for _, check := range checks {
+		if err := check(keywords, instance, path, result, depth); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (v *schemaValidator) checkGeneric(keywords map[string]*yamlv3.Node, instance *yamlv3.Node, path Path, result *evaluation, _ int) error {
+	if typeNode, ok := keywords["type"]; ok {
+		allowed := []string{typeNode.Value}
+		if typeNode.Kind == yamlv3.SequenceNode {
+			allowed = scalarValues(typeNode)
+		}
+
+		if !matchesJSONType(instance, allowed) {
+			result.report(path, instance, "type", "expected %s but found %s",
+				strings.Join(allowed, " or "),
+				jsonTypeOf(instance),
+			)
+		}
+	}
+
+	if enum, ok := keywords["enum"]; ok && enum.Kind == yamlv3.SequenceNode {
+		value, found := jsonValueOf(instance), false
+		for _, entry := range enum.Content {
+			if reflect.DeepEqual(value, jsonValueOf(entry)) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			result.report(path, instance, "enum", "value must be one of %s", strings.Join(scalarValues(enum), ", "))
+		}
+	}
+
+	if constant, ok := keywords["const"]; ok {
+		if !reflect.DeepEqual(jsonValueOf(instance), jsonValueOf(constant)) {
+			result.report(path, instance, "const", "value must be %s", describeValue(constant))
+		}
+	}
+
+	return nil
+}
+
+func (v *schemaValidator) checkNumber(keywords map[string]*yamlv3.Node, instance *yamlv3.Node, path Path, result *evaluation, _ int) error {
+	value, ok := numberOf(instance)
+	if !ok {
+		return nil
+	}
+
+	limits := []struct {
+		keyword   string
+		violation func(value, limit float64) bool
+		message   string
+	}{
+		{"minimum", func(value, limit float64) bool { return value < limit }, "value must be greater than or equal to %v"},
+		{"maximum", func(value, limit float64) bool { return value > limit }, "value must be less than or equal to %v"},
+		{"exclusiveMinimum", func(value, limit float64) bool { return value <= limit }, "value must be greater than %v"},
+		{"exclusiveMaximum", func(value, limit float64) bool { return value >= limit }, "value must be less than %v"},
+	}
+
+	for _, limit := range limits {
+		if node, ok := keywords[limit.keyword]; ok {
+			if limitValue, ok := numberOf(node); ok && limit.violation(value, limitValue) {
+				result.report(path, instance, limit.keyword, limit.message, limitValue)
+			}
+		}
+	}
+
+	if node, ok := keywords["multipleOf"]; ok {
+		if divisor, ok := numberOf(node); ok && divisor > 0 {
+			quotient := value / divisor
+			if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+				result.report(path, instance, "multipleOf", "value must be a multiple of %v", divisor)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *schemaValidator) checkString(keywords map[string]*yamlv3.Node, instance *yamlv3.Node, path Path, result *evaluation, _ int) error {
+	if jsonTypeOf(instance) != jsonTypeString {
+		return nil
+	}
+
+	length := utf8.RuneCountInString(instance.Value)
+	if limit, ok := intKeyword(keywords, "minLength"); ok && length < limit {
+		result.report(path, instance, "minLength", "string must be at least %d characters long", limit)
+	}
+
+	if limit, ok := intKeyword(keywords, "maxLength"); ok && length > limit {
+		result.report(path, instance, "maxLength", "string must be at most %d characters long", limit)
+	}
+
+	if pattern, ok := keywords["pattern"]; ok {
+		re, err := v.regexp(pattern.Value)
+		if err != nil {
+			return err
+		}
+
+		if !re.MatchString(instance.Value) {
+			result.report(path, instance, "pattern", "string must match pattern %s", pattern.Value)
+		}
+	}
+
+	return nil
+}
+
+func (v *schemaValidator) checkArray(keywords map[string]*yamlv3.Node, instance *yamlv3.Node, path Path, result *evaluation, depth int) error {
+	if instance.Kind != yamlv3.SequenceNode {
+		return nil
+	}
+
+	count := len(instance.Content)
+	if limit, ok := intKeyword(keywords, "minItems"); ok && count < limit {
+		result.report(path, instance, "minItems", "list must have at least %d entries", limit)
+	}
+
+	if limit, ok := intKeyword(keywords, "maxItems"); ok && count > limit {
+		result.report(path, instance, "maxItems", "list must have at most %d entries", limit)
+	}
+
+	if unique, ok := keywords["uniqueItems"]; ok && unique.Value == "true" {
+		for i := 0; i < count; i++ {
+			for j := i + 1; j < count; j++ {
+				if reflect.DeepEqual(jsonValueOf(instance.Content[i]), jsonValueOf(instance.Content[j])) {
+					result.report(itemPath(path, instance, j), instance.Content[j], "uniqueItems", "list entries must be unique, entry is a duplicate of entry #%d", i+1)
+				}
+			}
+		}
+	}
+
+	prefixCount := 0
+	if prefixItems, ok := keywords["prefixItems"]; ok && prefixItems.Kind == yamlv3.SequenceNode {
+		for i := 0; i < count && i < len(prefixItems.Content); i++ {
+			sub, err := v.validate(prefixItems.Content[i], instance.Content[i], itemPath(path, instance, i), depth+1)
+			if err != nil {
+				return err
+			}
+
+			result.violations = append(result.violations, sub.violations...)
+			result.items[i] = struct{}{}
+			prefixCount++
+		}
+	}
+
+	if items, ok := keywords["items"]; ok {
+		for i := prefixCount; i < count; i++ {
+			sub, err := v.validate(items, instance.Content[i], itemPath(path, instance, i), depth+1)
+			if err != nil {
+				return err
+			}
+
+			result.violations = append(result.violations, sub.violations...)
+			result.items[i] = struct{}{}
+		}
+	}
+
+	if contains, ok := keywords["contains"]; ok {
+		matches := 0
+		for i := 0; i < count; i++ {
+			sub, err := v.validate(contains, instance.Content[i], itemPath(path, instance, i), depth+1)
+			if err != nil {
+				return err
+			}
+
+			if sub.valid() {
+				result.items[i] = struct{}{}
+				matches++
+			}
+		}
+
+		minContains, ok := intKeyword(keywords, "minContains")
+		if !ok {
+			minContains = 1
+		}
+
+		if matches < minContains {
+			result.report(path, instance, "contains", "list must contain at least %d matching entries, found %d", minContains, matches)
+		}
+
+		if maxContains, ok := intKeyword(keywords, "maxContains"); ok && matches > maxContains {
+			result.report(path, instance, "maxContains", "list must contain at most %d matching entries, found %d", maxContains, matches)
+		}
+	}
+
+	return nil
+}
+
+func (v *schemaValidator) checkObject(keywords map[string]*yamlv3.Node, instance *yamlv3.Node, path Path, result *evaluation, depth int) error {
+	if instance.Kind != yamlv3.MappingNode {
+		return nil
+	}
+
+	count := len(instance.Content) / 2
+	if limit, ok := intKeyword(keywords, "minProperties"); ok && count < limit {
+		result.report(path, instance, "minProperties", "map must have at least %d keys", limit)
+	}
+
+	if limit, ok := intKeyword(keywords, "maxProperties"); ok && count > limit {
+		result.report(path, instance, "maxProperties", "map must have at most %d keys", limit)
+	}
+
+	if required, ok := keywords["required"]; ok {
+		for _, key := range scalarValues(required) {
+			if _, err := getValueByKey(instance, key); err != nil {
+				result.report(path, instance, "required", "required key '%s' is missing", key)
+			}
+		}
+	}
+
+	if dependentRequired, ok := keywords["dependentRequired"]; ok && dependentRequired.Kind == yamlv3.MappingNode {
+		for i := 0; i < len(dependentRequired.Content); i += 2 {
+			key, dependencies := dependentRequired.Content[i].Value, dependentRequired.Content[i+1]
+			if _, err := getValueByKey(instance, key); err != nil {
+				continue
+			}
+
+			for _, dependency := range scalarValues(dependencies) {
+				if _, err := getValueByKey(instance, dependency); err != nil {
+					result.report(path, instance, "dependentRequired", "key '%s' is required when key '%s' is present", dependency, key)
+				}
+			}
+		}
+	}
+
+	properties := keywords["properties"]
+	patternProperties := keywords["patternProperties"]
+	additionalProperties, hasAdditionalProperties := keywords["additionalProperties"]
+	propertyNames, hasPropertyNames := keywords["propertyNames"]
+
+	for i := 0; i < len(instance.Content); i += 2 {
+		k, value := instance.Content[i], instance.Content[i+1]
+		valuePath := NewPathWithNamedElement(path, k.Value)
+
+		if hasPropertyNames {
+			sub, err := v.validate(propertyNames, k, valuePath, depth+1)
+			if err != nil {
+				return err
+			}
+
+			if !sub.valid() {
+				result.report(valuePath, k, "propertyNames", "key '%s' is not a valid key name", k.Value)
+			}
+		}
+
+		var subschemas []*yamlv3.Node
+		if properties != nil && properties.Kind == yamlv3.MappingNode {
+			if subschema, err := getValueByKey(properties, k.Value); err == nil {
+				subschemas = append(subschemas, subschema)
+			}
+		}
+
+		if patternProperties != nil && patternProperties.Kind == yamlv3.MappingNode {
+			for j := 0; j < len(patternProperties.Content); j += 2 {
+				re, err := v.regexp(patternProperties.Content[j].Value)
+				if err != nil {
+					return err
+				}
+
+				if re.MatchString(k.Value) {
+					subschemas = append(subschemas, patternProperties.Content[j+1])
+				}
+			}
+		}
+
+		if len(subschemas) == 0 && hasAdditionalProperties {
+			if isFalseSchema(additionalProperties) {
+				result.report(valuePath, k, "additionalProperties", "key '%s' is not allowed", k.Value)
+				result.properties[k.Value] = struct{}{}
+				continue
+			}
+
+			subschemas = append(subschemas, additionalProperties)
+		}
+
+		for _, subschema := range subschemas {
+			sub, err := v.validate(subschema, value, valuePath, depth+1)
+			if err != nil {
+				return err
+			}
+
+			result.violations = append(result.violations, sub.violations...)
+			result.properties[k.Value] = struct{}{}
+		}
+	}
+
+	if dependentSchemas, ok := keywords["dependentSchemas"]; ok && dependentSchemas.Kind == yamlv3.MappingNode {
+		for i := 0; i < len(dependentSchemas.Content); i += 2 {
+			if _, err := getValueByKey(instance, dependentSchemas.Content[i].Value); err != nil {
+				continue
+			}
+
+			sub, err := v.validate(dependentSchemas.Content[i+1], instance, path, depth+1)
+			if err != nil {
+				return err
+			}
+
+			result.merge(sub)
+		}
+	}
+
+	return nil
+}
+
+func (v *schemaValidator) checkApplicators(keywords map[string]*yamlv3.Node, instance *yamlv3.Node, path Path, result *evaluation, depth int) error {
+	if allOf, ok := keywords["allOf"]; ok {
+		for _, subschema := range allOf.Content {
+			sub, err := v.validate(subschema, instance, path, depth+1)
+			if err != nil {
+				return err
+			}
+
+			result.merge(sub)
+		}
+	}
+
+	if anyOf, ok := keywords["anyOf"]; ok {
+		matches := 0
+		for _, subschema := range anyOf.Content {
+			sub, err := v.validate(subschema, instance, path, depth+1)
+			if err != nil {
+				return err
+			}
+
+			if sub.valid() {
+				result.mergeAnnotations(sub)
+				matches++
+			}
+		}
+
+		if matches == 0 {
+			result.report(path, instance, "anyOf", "value does not match any of the allowed schemas")
+		}
+	}
+
+	if oneOf, ok := keywords["oneOf"]; ok {
+		matches := 0
+		for _, subschema := range oneOf.Content {
+			sub, err := v.validate(subschema, instance, path, depth+1)
+			if err != nil {
+				return err
+			}
+
+			if sub.valid() {
+				result.mergeAnnotations(sub)
+				matches++
+			}
+		}
+
+		if matches != 1 {
+			result.report(path, instance, "oneOf", "value must match exactly one of the allowed schemas, but matches %d", matches)
+		}
+	}
+
+	if not, ok := keywords["not"]; ok {
+		sub, err := v.validate(not, instance, path, depth+1)
+		if err != nil {
+			return err
+		}
+
+		if sub.valid() {
+			result.report(path, instance, "not", "value must not match the schema")
+		}
+	}
+
+	if ifSchema, ok := keywords["if"]; ok {
+		sub, err := v.validate(ifSchema, instance, path, depth+1)
+		if err != nil {
+			return err
+		}
+
+		branch := "else"
+		if sub.valid() {
+			result.mergeAnnotations(sub)
+			branch = "then"
+		}
+
+		if branchSchema, ok := keywords[branch]; ok {
+			sub, err := v.validate(branchSchema, instance, path, depth+1)
+			if err != nil {
+				return err
+			}
+
+			result.merge(sub)
+		}
+	}
+
+	return nil
+}
+
+func (v *schemaValidator) checkUnevaluated(keywords map[string]*yamlv3.Node, instance *yamlv3.Node, path Path, result *evaluation, depth int) error {
+	if unevaluatedProperties, ok := keywords["unevaluatedProperties"]; ok && instance.Kind == yamlv3.MappingNode {
+		for i := 0; i < len(instance.Content); i += 2 {
+			k, value := instance.Content[i], instance.Content[i+1]
+			if _, evaluated := result.properties[k.Value]; evaluated {
+				continue
+			}
+
+			valuePath := NewPathWithNamedElement(path, k.Value)
+			if isFalseSchema(unevaluatedProperties) {
+				result.report(valuePath, k, "unevaluatedProperties", "key '%s' is not allowed", k.Value)
+				continue
+			}
+
+			sub, err := v.validate(unevaluatedProperties, value, valuePath, depth+1)
+			if err != nil {
+				return err
+			}
+
+			result.violations = append(result.violations, sub.violations...)
+			result.properties[k.Value] = struct{}{}
+		}
+	}
+
+	if unevaluatedItems, ok := keywords["unevaluatedItems"]; ok && instance.Kind == yamlv3.SequenceNode {
+		for i, entry := range instance.Content {
+			if _, evaluated := result.items[i]; evaluated {
+				continue
+			}
+
+			sub, err := v.validate(unevaluatedItems, entry, itemPath(path, instance, i), depth+1)
+			if err != nil {
+				return err
+			}
+
+			result.violations = append(result.violations, sub.violations...)
+			result.items[i] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// itemPath returns the path of a list entry, which uses the identifier for
+// named-entry lists like all other paths in this package
+func itemPath(path Path, sequenceNode *yamlv3.Node, idx int) Path {
+	if identifier := DefaultIdentifierResolver.Identifier(path, sequenceNode); identifier != nil {
+		if names, err := getNameByIdentifier(sequenceNode.Content[idx], identifier); err == nil {
+			return NewPathWithPathElement(path, namedListElement(identifier, names))
+		}
+	}
+
+	return NewPathWithIndexedListElement(path, idx)
+}
+
+func isFalseSchema(schema *yamlv3.Node) bool {
+	if schema.Kind != yamlv3.ScalarNode || schema.ShortTag() != tagBool {
+		return false
+	}
+
+	value, err := strconv.ParseBool(schema.Value)
+	return err == nil && !value
+}
+
+func intKeyword(keywords map[string]*yamlv3.Node, keyword string) (int, bool) {
+	node, ok := keywords[keyword]
+	if !ok {
+		return 0, false
+	}
+
+	value, ok := numberOf(node)
+	return int(value), ok
+}
+
+func scalarValues(node *yamlv3.Node) []string {
+	if node.Kind == yamlv3.ScalarNode {
+		return []string{node.Value}
+	}
+
+	result := make([]string, 0, len(node.Content))
+	for _, entry := range node.Content {
+		result = append(result, describeValue(entry))
+	}
+
+	return result
+}
+
+func describeValue(node *yamlv3.Node) string {
+	if node.Kind == yamlv3.ScalarNode {
+		return node.Value
+	}
+
+	return TypeOf(node).String()
+}
+
+// jsonTypeOf returns the JSON type name of the provided YAML node
+func jsonTypeOf(node *yamlv3.Node) string {
+	if node.Kind == yamlv3.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		return jsonTypeObject
+
+	case yamlv3.SequenceNode:
+		return jsonTypeArray
+	}
+
+	switch node.ShortTag() {
+	case tagInt:
+		return jsonTypeInteger
+
+	case tagFloat:
+		if value, ok := numberOf(node); ok && value == math.Trunc(value) && !math.IsInf(value, 0) {
+			return jsonTypeInteger
+		}
+
+		return jsonTypeNumber
+
+	case tagBool:
+		return jsonTypeBoolean
+
+	case tagNull:
+		return jsonTypeNull
+
+	default:
+		return jsonTypeString
+	}
+}
+
+func matchesJSONType(node *yamlv3.Node, allowed []string) bool {
+	actual := jsonTypeOf(node)
+	for _, name := range allowed {
+		if name == actual || (name == jsonTypeNumber && actual == jsonTypeInteger) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func numberOf(node *yamlv3.Node) (float64, bool) {
+	if node.Kind != yamlv3.ScalarNode {
+		return 0, false
+	}
+
+	switch node.ShortTag() {
+	case tagInt, tagFloat:
+		var value float64
+		if err := node.Decode(&value); err != nil {
+			return 0, false
+		}
+
+		return value, true
+	}
+
+	return 0, false
+}
+
+// jsonValueOf returns a Go representation of the node that can be compared
+// using JSON semantics, where all numbers are equal if they have the same value
+func jsonValueOf(node *yamlv3.Node) interface{} {
+	if node.Kind == yamlv3.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		result := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i < len(node.Content); i += 2 {
+			result[node.Content[i].Value] = jsonValueOf(node.Content[i+1])
+		}
+
+		return result
+
+	case yamlv3.SequenceNode:
+		result := make([]interface{}, len(node.Content))
+		for i, entry := range node.Content {
+			result[i] = jsonValueOf(entry)
+		}
+
+		return result
+	}
+
+	switch jsonTypeOf(node) {
+	case jsonTypeInteger, jsonTypeNumber:
+		value, _ := numberOf(node)
+		return value
+
+	case jsonTypeBoolean:
+		var value bool
+		_ = node.Decode(&value)
+		return value
+
+	case jsonTypeNull:
+		return nil
+
+	default:
+		return node.Value
+	}
+}
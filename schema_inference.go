@@ -0,0 +1,287 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"math/big"
+	"slices"
+	"sort"
+	"strconv"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// identifierAnnotation is the (non-validating) keyword used in inferred
+// schemas to document the identifier of a named-entry list
+const identifierAnnotation = "x-ytbx-identifier"
+
+// SchemaInferenceOptions controls how a schema is inferred from documents
+type SchemaInferenceOptions struct {
+	// EnumLimit is the maximum number of distinct values for a string or
+	// integer field to be described as an enum, zero disables enums. Values
+	// are only considered to be an enum if at least one of them is repeated.
+	EnumLimit int
+}
+
+// DefaultSchemaInferenceOptions are the options suitable for most use cases
+var DefaultSchemaInferenceOptions = SchemaInferenceOptions{
+	EnumLimit: 5,
+}
+
+// InferSchema generates a JSON Schema (draft 2020-12) that describes all
+// documents of the provided input files, which is meant to bootstrap a
+// schema for configuration that is not documented yet. The schema contains
+// the observed keys and their types, marks keys that appear in all
+// observed maps as required, describes repeating values as enums, and
+// documents the identifier of named-entry lists using the
+// `x-ytbx-identifier` annotation, which is a list of keys for composite
+// identifiers. Any of the input documents is valid against the result.
+func InferSchema(opts SchemaInferenceOptions, inputFiles ...InputFile) *yamlv3.Node {
+	root := newInferredNode()
+	for _, inputFile := range inputFiles {
+		for idx, document := range inputFile.Documents {
+			root.observe(Path{Root: &inputFile, DocumentIdx: idx}, documentRoot(document))
+		}
+	}
+
+	schema := root.schema(opts)
+	schema.Content = append([]*yamlv3.Node{
+		newScalarNode(tagString, "$schema"),
+		newScalarNode(tagString, "https://json-schema.org/draft/2020-12/schema"),
+	}, schema.Content...)
+
+	return schema
+}
+
+// inferredNode collects what was observed at one location of the documents
+type inferredNode struct {
+	count int
+	types map[string]struct{}
+
+	objects    int
+	keys       []string
+	properties map[string]*inferredNode
+
+	items              *inferredNode
+	identifier         []string
+	identifierConflict bool
+
+	scalars int
+	values  []string
+	seen    map[string]struct{}
+}
+
+func newInferredNode() *inferredNode {
+	return &inferredNode{
+		types:      map[string]struct{}{},
+		properties: map[string]*inferredNode{},
+		seen:       map[string]struct{}{},
+	}
+}
+
+func (n *inferredNode) observe(path Path, node *yamlv3.Node) {
+	if node.Kind == yamlv3.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	n.count++
+	jsonType := jsonTypeOf(node)
+	n.types[jsonType] = struct{}{}
+
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		n.objects++
+		for i := 0; i < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			property, ok := n.properties[k.Value]
+			if !ok {
+				property = newInferredNode()
+				n.properties[k.Value] = property
+				n.keys = append(n.keys, k.Value)
+			}
+
+			property.observe(NewPathWithNamedElement(path, k.Value), v)
+		}
+
+	case yamlv3.SequenceNode:
+		identifier := DefaultIdentifierResolver.Identifier(path, node)
+		if n.items == nil {
+			n.items = newInferredNode()
+			n.identifier = identifier
+
+		} else if !slices.Equal(n.identifier, identifier) {
+			n.identifierConflict = true
+		}
+
+		for idx, entry := range node.Content {
+			n.items.observe(itemPath(path, node, idx), entry)
+		}
+
+	case yamlv3.ScalarNode:
+		var value string
+		switch jsonType {
+		case jsonTypeString:
+			value = node.Value
+
+		case jsonTypeInteger:
+			value = integerValueOf(node)
+
+		default:
+			return
+		}
+
+		n.scalars++
+		if _, ok := n.seen[value]; !ok {
+			n.seen[value] = struct{}{}
+			n.values = append(n.values, value)
+		}
+	}
+}
+
+func (n *inferredNode) schema(opts SchemaInferenceOptions) *yamlv3.Node {
+	schema := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}
+	add := func(key string, value *yamlv3.Node) {
+		schema.Content = append(schema.Content, newScalarNode(tagString, key), value)
+	}
+
+	types := n.typeNames()
+	switch len(types) {
+	case 0:
+		return schema
+
+	case 1:
+		add("type", newScalarNode(tagString, types[0]))
+
+	default:
+		typeList := &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq", Style: yamlv3.FlowStyle}
+		for _, name := range types {
+			typeList.Content = append(typeList.Content, newScalarNode(tagString, name))
+		}
+
+		add("type", typeList)
+	}
+
+	if len(n.keys) > 0 {
+		properties := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}
+		required := &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq", Style: yamlv3.FlowStyle}
+		for _, key := range n.keys {
+			property := n.properties[key]
+			properties.Content = append(properties.Content,
+				newScalarNode(tagString, key),
+				property.schema(opts),
+			)
+
+			if property.count >= n.objects {
+				required.Content = append(required.Content, newScalarNode(tagString, key))
+			}
+		}
+
+		add("properties", properties)
+		if len(required.Content) > 0 {
+			add("required", required)
+		}
+	}
+
+	if n.items != nil {
+		add("items", n.items.schema(opts))
+		switch {
+		case n.identifierConflict || n.identifier == nil:
+			// nothing to document, since the identifier is unknown or ambiguous
+
+		case len(n.identifier) == 1:
+			add(identifierAnnotation, newScalarNode(tagString, n.identifier[0]))
+
+		default:
+			keys := &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq", Style: yamlv3.FlowStyle}
+			for _, key := range n.identifier {
+				keys.Content = append(keys.Content, newScalarNode(tagString, key))
+			}
+
+			add(identifierAnnotation, keys)
+		}
+	}
+
+	if enum := n.enum(types, opts); enum != nil {
+		add("enum", enum)
+	}
+
+	return schema
+}
+
+// typeNames returns the sorted JSON type names, where integer is dropped
+// in favor of number if both were observed
+func (n *inferredNode) typeNames() []string {
+	_, hasNumber := n.types[jsonTypeNumber]
+
+	var result []string
+	for name := range n.types {
+		if name == jsonTypeInteger && hasNumber {
+			continue
+		}
+
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// enum returns the enum values of the node, which are only used for plain
+// string or integer values with a limited set of repeating values
+func (n *inferredNode) enum(types []string, opts SchemaInferenceOptions) *yamlv3.Node {
+	if len(types) != 1 || (types[0] != jsonTypeString && types[0] != jsonTypeInteger) {
+		return nil
+	}
+
+	if len(n.values) == 0 || len(n.values) > opts.EnumLimit || n.scalars <= len(n.values) {
+		return nil
+	}
+
+	tag := tagString
+	if types[0] == jsonTypeInteger {
+		tag = tagInt
+	}
+
+	enum := &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq", Style: yamlv3.FlowStyle}
+	for _, value := range n.values {
+		enum.Content = append(enum.Content, newScalarNode(tag, value))
+	}
+
+	return enum
+}
+
+// integerValueOf returns the decimal representation of an integer node, where
+// integers are parsed without loss of precision and only floats with an
+// integral value, like `1.0`, are converted from their float value
+func integerValueOf(node *yamlv3.Node) string {
+	if node.ShortTag() == tagInt {
+		if number, ok := new(big.Int).SetString(node.Value, 0); ok {
+			return number.String()
+		}
+	}
+
+	number, _ := numberOf(node)
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+func newScalarNode(tag string, value string) *yamlv3.Node {
+	return &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: tag, Value: value}
+}
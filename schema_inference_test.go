@@ -0,0 +1,112 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+var _ = Describe("Schema inference", func() {
+	Context("Inferring a JSON Schema from documents", func() {
+		It("should describe the observed keys, types, and required keys", func() {
+			schema := InferSchema(DefaultSchemaInferenceOptions, inputFile(
+				`{name: web, replicas: 3, debug: true}`,
+				`{name: db, replicas: 1.5, labels: {tier: backend}}`,
+			))
+
+			Expect(grab(schema, "/$schema")).To(BeEquivalentTo("https://json-schema.org/draft/2020-12/schema"))
+			Expect(grab(schema, "/type")).To(BeEquivalentTo("object"))
+			Expect(grab(schema, "/required")).To(BeAsNode(list(`[name, replicas]`)))
+			Expect(grab(schema, "/properties/name/type")).To(BeEquivalentTo("string"))
+			Expect(grab(schema, "/properties/replicas/type")).To(BeEquivalentTo("number"))
+			Expect(grab(schema, "/properties/debug/type")).To(BeEquivalentTo("boolean"))
+			Expect(grab(schema, "/properties/labels/properties/tier/type")).To(BeEquivalentTo("string"))
+		})
+
+		It("should list all types observed for a key", func() {
+			schema := InferSchema(DefaultSchemaInferenceOptions, inputFile(`{a: foo}`, `{a: ~}`, `{a: [1]}`))
+			Expect(grab(schema, "/properties/a/type")).To(BeAsNode(list(`[array, "null", string]`)))
+			Expect(grab(schema, "/properties/a/items/type")).To(BeEquivalentTo("integer"))
+		})
+
+		It("should describe repeating values as enums", func() {
+			schema := InferSchema(DefaultSchemaInferenceOptions, inputFile(
+				`{env: prod, port: 80, id: a}`,
+				`{env: dev, port: 80, id: b}`,
+				`{env: prod, port: 443, id: c}`,
+			))
+
+			Expect(grab(schema, "/properties/env/enum")).To(BeAsNode(list(`[prod, dev]`)))
+			Expect(grab(schema, "/properties/port/enum")).To(BeAsNode(list(`[80, 443]`)))
+			Expect(grabError(schema, "/properties/id/enum")).To(ContainSubstring("no key 'enum' found"))
+
+			schema = InferSchema(SchemaInferenceOptions{}, inputFile(`{env: prod}`, `{env: prod}`))
+			Expect(grabError(schema, "/properties/env/enum")).To(ContainSubstring("no key 'enum' found"))
+		})
+
+		It("should keep the precision of large integers in enums", func() {
+			schema := InferSchema(DefaultSchemaInferenceOptions, inputFile(
+				`{id: 9007199254740993}`,
+				`{id: 9007199254740993}`,
+				`{id: 0x10}`,
+			))
+
+			Expect(grab(schema, "/properties/id/enum")).To(BeAsNode(list(`[9007199254740993, 16]`)))
+		})
+
+		It("should document the identifier of named-entry lists", func() {
+			schema := InferSchema(DefaultSchemaInferenceOptions, inputFile(
+				`{jobs: [{name: a, image: foo}, {name: b}]}`,
+			))
+
+			Expect(grab(schema, "/properties/jobs/x-ytbx-identifier")).To(BeEquivalentTo("name"))
+			Expect(grab(schema, "/properties/jobs/items/required")).To(BeAsNode(list(`[name]`)))
+			Expect(grab(schema, "/properties/jobs/items/properties/image/type")).To(BeEquivalentTo("string"))
+		})
+
+		It("should infer a schema that all input documents are valid against", func() {
+			inputFile, err := LoadFile(assets("kubernetes", "list.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			schema := InferSchema(DefaultSchemaInferenceOptions, inputFile)
+
+			// Check that the result can be used like any loaded document
+			data, err := yamlv3.Marshal(schema)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).ToNot(BeEmpty())
+
+			for _, document := range inputFile.Documents {
+				violations, err := ValidateAgainstSchema(document, schema)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(violations).To(BeEmpty())
+			}
+
+			violations, err := ValidateAgainstSchema(yml(`{foo: bar}`), schema)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(violations).ToNot(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,212 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+)
+
+var _ = Describe("Schema validation", func() {
+	violations := func(doc string, schema string) []string {
+		result, err := ValidateAgainstSchema(yml(doc), yml(schema))
+		Expect(err).ToNot(HaveOccurred())
+
+		var messages []string
+		for _, violation := range result {
+			messages = append(messages, violation.Path.ToGoPatchStyle()+": "+violation.Message)
+		}
+
+		return messages
+	}
+
+	Context("Validating documents against a JSON Schema", func() {
+		It("should accept a valid document", func() {
+			Expect(violations(`{name: web, replicas: 3, ports: [80, 443]}`, `{
+  type: object,
+  required: [name, replicas],
+  properties: {
+    name: {type: string, minLength: 1},
+    replicas: {type: integer, minimum: 1},
+    ports: {type: array, items: {type: integer}, uniqueItems: true}
+  }
+}`)).To(BeEmpty())
+		})
+
+		It("should report violations with path and position", func() {
+			result, err := ValidateAgainstSchema(yml(`---
+name: web
+spec:
+  replicas: three
+`), yml(`{properties: {spec: {properties: {replicas: {type: integer}}}}}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Path.ToGoPatchStyle()).To(Equal("/spec/replicas"))
+			Expect(result[0].Line).To(Equal(4))
+			Expect(result[0].Column).To(Equal(13))
+			Expect(result[0].Keyword).To(Equal("type"))
+			Expect(result[0].Message).To(Equal("expected integer but found string"))
+		})
+
+		It("should use named-entry list paths for list entries", func() {
+			Expect(violations(`{jobs: [{name: a, instances: 1}, {name: b, instances: -1}]}`,
+				`{properties: {jobs: {items: {properties: {instances: {minimum: 0}}}}}}`,
+			)).To(ConsistOf("/jobs/name=b/instances: value must be greater than or equal to 0"))
+		})
+
+		It("should report missing and additional keys", func() {
+			Expect(violations(`{name: web, extra: true}`,
+				`{required: [name, image], properties: {name: true}, additionalProperties: false}`,
+			)).To(ConsistOf(
+				"/: required key 'image' is missing",
+				"/extra: key 'extra' is not allowed",
+			))
+		})
+
+		It("should check string, number, and list constraints", func() {
+			Expect(violations(`{s: abc, n: 7, l: [1, 1]}`, `{properties: {
+  s: {maxLength: 2, pattern: "^x"},
+  n: {multipleOf: 2, exclusiveMaximum: 7},
+  l: {maxItems: 1, uniqueItems: true}
+}}`)).To(ConsistOf(
+				"/s: string must be at most 2 characters long",
+				"/s: string must match pattern ^x",
+				"/n: value must be less than 7",
+				"/n: value must be a multiple of 2",
+				"/l: list must have at most 1 entries",
+				"/l/1: list entries must be unique, entry is a duplicate of entry #1",
+			))
+		})
+
+		It("should compare values using JSON semantics", func() {
+			Expect(violations(`{a: 1.0, b: yes}`, `{properties: {a: {const: 1, type: integer}, b: {enum: [foo, bar]}}}`)).To(ConsistOf(
+				"/b: value must be one of foo, bar",
+			))
+		})
+
+		It("should support the applicator keywords", func() {
+			schema := `{
+  properties: {
+    any: {anyOf: [{type: string}, {type: integer}]},
+    one: {oneOf: [{type: integer}, {minimum: 0}]},
+    not: {not: {type: "null"}},
+    cond: {if: {properties: {kind: {const: a}}}, then: {required: [foo]}, else: {required: [bar]}}
+  }
+}`
+			Expect(violations(`{any: 1, one: -1, not: x, cond: {kind: a, foo: 1}}`, schema)).To(BeEmpty())
+			Expect(violations(`{any: true, one: 1, not: ~, cond: {kind: b, foo: 1}}`, schema)).To(ConsistOf(
+				"/any: value does not match any of the allowed schemas",
+				"/one: value must match exactly one of the allowed schemas, but matches 2",
+				"/not: value must not match the schema",
+				"/cond: required key 'bar' is missing",
+			))
+		})
+
+		It("should resolve references to definitions and anchors", func() {
+			schema := `{
+  $defs: {port: {type: integer, maximum: 65535}, name: {$anchor: name, type: string}},
+  properties: {port: {$ref: "#/$defs/port"}, name: {$ref: "#name"}}
+}`
+			Expect(violations(`{port: 99999, name: 1}`, schema)).To(ConsistOf(
+				"/port: value must be less than or equal to 65535",
+				"/name: expected string but found integer",
+			))
+		})
+
+		It("should unescape each JSON pointer token separately", func() {
+			schema := `{
+  $defs: {"a/b": {type: integer}, "c~d": {type: string}},
+  properties: {x: {$ref: "#/$defs/a%2Fb"}, y: {$ref: "#/$defs/a~1b"}, z: {$ref: "#/$defs/c~0d"}}
+}`
+			Expect(violations(`{x: foo, y: bar, z: 1}`, schema)).To(ConsistOf(
+				"/x: expected integer but found string",
+				"/y: expected integer but found string",
+				"/z: expected string but found integer",
+			))
+		})
+
+		It("should not register property names as anchors or identifiers", func() {
+			_, err := ValidateAgainstSchema(yml(`{a: 1}`), yml(`{properties: {$anchor: true, a: {$ref: "#true"}}}`))
+			Expect(err).To(MatchError(ContainSubstring("there is no anchor true")))
+		})
+
+		It("should support recursive schemas", func() {
+			schema := `{properties: {name: {type: string}, children: {items: {$ref: "#"}}}}`
+			Expect(violations(`{name: a, children: [{name: b, children: [{name: 1}]}]}`, schema)).To(ConsistOf(
+				"/children/name=b/children/name=1/name: expected string but found integer",
+			))
+		})
+
+		It("should support tuples and contains", func() {
+			schema := `{prefixItems: [{type: string}, {type: integer}], items: false, contains: {const: 42}}`
+			Expect(violations(`[foo, 42]`, schema)).To(BeEmpty())
+			Expect(violations(`[foo, 1, bar]`, schema)).To(ConsistOf(
+				"/2: value is not allowed",
+				"/: list must contain at least 1 matching entries, found 0",
+			))
+		})
+
+		It("should track evaluated keys for unevaluatedProperties", func() {
+			schema := `{allOf: [{properties: {a: true}}], patternProperties: {"^x-": true}, unevaluatedProperties: false}`
+			Expect(violations(`{a: 1, x-foo: 2, b: 3}`, schema)).To(ConsistOf(
+				"/b: key 'b' is not allowed",
+			))
+		})
+
+		It("should not report keys rejected by additionalProperties again", func() {
+			schema := `{properties: {a: true}, additionalProperties: false, unevaluatedProperties: false}`
+			Expect(violations(`{a: 1, b: 2}`, schema)).To(ConsistOf(
+				"/b: key 'b' is not allowed",
+			))
+		})
+
+		It("should ignore evaluated keys of failed subschemas", func() {
+			schema := `{allOf: [{properties: {a: {type: string}}}], unevaluatedProperties: false}`
+			Expect(violations(`{a: 1}`, schema)).To(ConsistOf(
+				"/a: expected string but found integer",
+				"/a: key 'a' is not allowed",
+			))
+		})
+
+		It("should fail for schemas that cannot be used", func() {
+			_, err := ValidateAgainstSchema(yml(`{a: 1}`), yml(`{$ref: "https://example.com/schema.json"}`))
+			Expect(err).To(HaveOccurred())
+
+			_, err = ValidateAgainstSchema(yml(`{a: foo}`), yml(`{properties: {a: {pattern: "("}}}`))
+			Expect(err).To(HaveOccurred())
+
+			_, err = ValidateAgainstSchema(yml(`{a: foo}`), yml(`{$ref: "#"}`))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail for dynamic references", func() {
+			_, err := ValidateAgainstSchema(yml(`{a: foo}`), yml(`{$defs: {x: {$dynamicAnchor: x, type: string}}, properties: {a: {$dynamicRef: "#x"}}}`))
+			Expect(err).To(MatchError("failed to validate, $dynamicRef is not supported"))
+		})
+
+		It("should detect false schemas regardless of the spelling", func() {
+			Expect(violations(`{a: 1}`, `{properties: {a: False}}`)).To(ConsistOf("/a: value is not allowed"))
+			Expect(violations(`{a: 1, b: 2}`, `{properties: {a: true}, additionalProperties: FALSE}`)).To(ConsistOf("/b: key 'b' is not allowed"))
+		})
+	})
+})
@@ -0,0 +1,140 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is the maximum number of suggestions offered for a typo
+const maxSuggestions = 3
+
+// suggest returns the candidates that are similar to the provided input, which
+// are candidates that only differ in case or separator characters (e.g.
+// `instance-groups` and `instance_groups`), or that are within a small edit
+// distance of the input. The best matches come first.
+func suggest(input string, candidates []string) []string {
+	type match struct {
+		candidate string
+		distance  int
+	}
+
+	normalizedInput := normalizeForSuggestion(input)
+	threshold := len(normalizedInput) / 3
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	var matches []match
+	for _, candidate := range candidates {
+		if candidate == input {
+			continue
+		}
+
+		// Candidates that need to be replaced completely are not similar, which
+		// is important for very short inputs like single letters or numbers
+		normalizedCandidate := normalizeForSuggestion(candidate)
+		distance := editDistance(normalizedInput, normalizedCandidate)
+		if distance <= threshold && distance < min(len(normalizedInput), len(normalizedCandidate)) {
+			matches = append(matches, match{candidate, distance})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	var result []string
+	for _, match := range matches {
+		if len(result) == maxSuggestions {
+			break
+		}
+
+		result = append(result, match.candidate)
+	}
+
+	return result
+}
+
+// normalizeForSuggestion returns the lowercase input without the separator
+// characters commonly used in keys
+func normalizeForSuggestion(input string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '.', ' ':
+			return -1
+
+		default:
+			return r
+		}
+	}, strings.ToLower(input))
+}
+
+// editDistance returns the optimal string alignment distance between the two
+// strings, which is the Levenshtein distance that also counts the transposition
+// of two adjacent characters as a single edit
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(ra)][len(rb)]
+}
+
+// didYouMean returns a human readable hint for the provided suggestions, or
+// an empty string if there are none
+func didYouMean(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		quoted[i] = fmt.Sprintf("'%s'", suggestion)
+	}
+
+	return fmt.Sprintf(", did you mean %s?", strings.Join(quoted, " or "))
+}
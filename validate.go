@@ -0,0 +1,155 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// IssueType is a custom type for the kinds of issues found by `Validate`
+type IssueType int
+
+// Supported issue types are duplicate keys in a map, which YAML forbids, and
+// duplicate identifiers in a named-entry list, which makes entries ambiguous
+const (
+	DuplicateKey IssueType = iota
+	DuplicateIdentifier
+)
+
+func (issueType IssueType) String() string {
+	switch issueType {
+	case DuplicateKey:
+		return "duplicate key"
+
+	case DuplicateIdentifier:
+		return "duplicate identifier"
+
+	default:
+		return "unknown issue"
+	}
+}
+
+// ValidationIssue describes a problem found in a document, where the path
+// refers to the ambiguous section and lines lists the line numbers of all
+// occurrences in the source
+type ValidationIssue struct {
+	Type  IssueType
+	Path  Path
+	Lines []int
+}
+
+func (issue ValidationIssue) String() string {
+	lines := make([]string, len(issue.Lines))
+	for i, line := range issue.Lines {
+		lines[i] = strconv.Itoa(line)
+	}
+
+	return fmt.Sprintf("%s %s in %s (lines %s)",
+		issue.Type,
+		issue.Path.ToGoPatchStyle(),
+		issue.Path.RootDescription(),
+		strings.Join(lines, ", "),
+	)
+}
+
+// Validate checks all documents of the input file for duplicate keys in maps
+// and duplicate identifiers in named-entry lists and returns all issues found
+func Validate(inputFile InputFile) []ValidationIssue {
+	var issues []ValidationIssue
+	for idx, document := range inputFile.Documents {
+		root := Path{Root: &inputFile, DocumentIdx: idx}
+		validateNode(root, documentRoot(document), func(issue ValidationIssue) {
+			issues = append(issues, issue)
+		})
+	}
+
+	return issues
+}
+
+func validateNode(path Path, node *yamlv3.Node, report func(ValidationIssue)) {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		occurrences, order := map[string][]int{}, []string{}
+		for i := 0; i < len(node.Content); i += 2 {
+			k := node.Content[i]
+			if _, ok := occurrences[k.Value]; !ok {
+				order = append(order, k.Value)
+			}
+
+			occurrences[k.Value] = append(occurrences[k.Value], k.Line)
+		}
+
+		for _, key := range order {
+			if lines := occurrences[key]; len(lines) > 1 {
+				report(ValidationIssue{
+					Type:  DuplicateKey,
+					Path:  NewPathWithNamedElement(path, key),
+					Lines: lines,
+				})
+			}
+		}
+
+		for i := 0; i < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			validateNode(NewPathWithNamedElement(path, k.Value), v, report)
+		}
+
+	case yamlv3.SequenceNode:
+		identifier := DefaultIdentifierResolver.Identifier(path, node)
+		if identifier == nil {
+			for idx, entry := range node.Content {
+				validateNode(NewPathWithIndexedListElement(path, idx), entry, report)
+			}
+
+			return
+		}
+
+		names := entryNames(node, identifier)
+		occurrences, elements, order := map[string][]int{}, map[string]PathElement{}, []string{}
+		for idx, entry := range node.Content {
+			name := names[idx]
+			if _, ok := occurrences[name]; !ok {
+				order = append(order, name)
+				elements[name] = namedListEntryElement(entry, identifier)
+			}
+
+			occurrences[name] = append(occurrences[name], entry.Line)
+		}
+
+		for _, name := range order {
+			if lines := occurrences[name]; len(lines) > 1 {
+				report(ValidationIssue{
+					Type:  DuplicateIdentifier,
+					Path:  NewPathWithPathElement(path, elements[name]),
+					Lines: lines,
+				})
+			}
+		}
+
+		for _, entry := range node.Content {
+			validateNode(NewPathWithPathElement(path, namedListEntryElement(entry, identifier)), entry, report)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ytbx_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/ytbx"
+)
+
+var duplicatesExample = `---
+name: deployment
+instance_groups:
+- name: web
+  instances: 1
+  instances: 2
+- name: worker
+  instances: 1
+- name: web
+  instances: 3
+`
+
+var _ = Describe("Validating documents", func() {
+	var input InputFile
+
+	BeforeEach(func() {
+		documents, err := LoadYAMLDocuments([]byte(duplicatesExample))
+		Expect(err).ToNot(HaveOccurred())
+		input = InputFile{Documents: documents}
+	})
+
+	Context("Checking for duplicates", func() {
+		It("should report duplicate keys and duplicate identifiers", func() {
+			issues := Validate(input)
+			Expect(issues).To(HaveLen(2))
+
+			Expect(issues[0].Type).To(Equal(DuplicateIdentifier))
+			Expect(issues[0].Path.String()).To(Equal("/instance_groups/name=web"))
+			Expect(issues[0].Lines).To(Equal([]int{4, 9}))
+
+			Expect(issues[1].Type).To(Equal(DuplicateKey))
+			Expect(issues[1].Path.String()).To(Equal("/instance_groups/name=web/instances"))
+			Expect(issues[1].Lines).To(Equal([]int{5, 6}))
+
+			Expect(issues[1].String()).To(Equal("duplicate key /instance_groups/name=web/instances in document #1 (lines 5, 6)"))
+		})
+
+		It("should not report anything for documents without duplicates", func() {
+			input, err := LoadFile(assets("bosh-yaml", "manifest.yml"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(Validate(input)).To(BeEmpty())
+		})
+	})
+
+	Context("Grabbing values in strict mode", func() {
+		It("should fail on ambiguous named-entry list entries", func() {
+			_, err := GrabStrict(input.Documents[0], "/instance_groups/name=web")
+			Expect(err).To(MatchError("path /instance_groups/name=web is ambiguous, it matches 2 entries in lines 4, 9"))
+
+			var ambiguous *AmbiguousPathError
+			Expect(err).To(BeAssignableToTypeOf(ambiguous))
+		})
+
+		It("should fail on ambiguous map keys", func() {
+			_, err := GrabStrict(input.Documents[0], "/instance_groups/name=worker/instances")
+			Expect(err).ToNot(HaveOccurred())
+
+			document, err := LoadYAMLDocuments([]byte("{ foo: 1, foo: 2 }"))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = GrabStrict(document[0], "/foo")
+			Expect(err).To(MatchError("path /foo is ambiguous, it matches 2 entries in lines 1, 1"))
+		})
+
+		It("should behave like the non-strict mode for unique paths", func() {
+			Expect(GrabStrict(input.Documents[0], "/name")).To(BeAsNode(yml(`deployment`)))
+		})
+	})
+})
@@ -136,6 +136,17 @@ func singleDoc(input string) *yamlv3.Node {
 	return docs[0]
 }
 
+func inputFile(documents ...string) ytbx.InputFile {
+	var result ytbx.InputFile
+	for _, document := range documents {
+		docs, err := ytbx.LoadDocuments([]byte("---\n" + document))
+		Expect(err).ToNot(HaveOccurred())
+		result.Documents = append(result.Documents, docs...)
+	}
+
+	return result
+}
+
 func grab(node *yamlv3.Node, path string) interface{} {
 	v, err := ytbx.Grab(node, path)
 	if err != nil {